@@ -0,0 +1,135 @@
+package handler
+
+import (
+	pb "auth-service/generated/auth_service"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// SearchUsersHandler searches users for admins
+// @Summary Search users
+// @Description Search by email, username, phone or name with filters and cursor pagination
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param q query string false "Email, username, phone or name"
+// @Param status query string false "pending_verification, active, suspended, deactivated or deleted"
+// @Param role query string false "Role"
+// @Param created_from query string false "Created from (RFC3339)"
+// @Param created_to query string false "Created to (RFC3339)"
+// @Param email_verified query string false "true or false"
+// @Param phone_verified query string false "true or false"
+// @Param sort_by query string false "created_at, username or email"
+// @Param sort_order query string false "asc or desc"
+// @Param cursor query string false "next_cursor from the previous page"
+// @Param limit query int false "Limit"
+// @Success 200 {object} auth_service.SearchUsersResponse
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users [get]
+func (h *Handler) SearchUsersHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling SearchUsersHandler request")
+
+	req := &pb.SearchUsersRequest{
+		Query:         ctx.Query("q"),
+		Status:        ctx.Query("status"),
+		Role:          ctx.Query("role"),
+		CreatedFrom:   ctx.Query("created_from"),
+		CreatedTo:     ctx.Query("created_to"),
+		EmailVerified: ctx.Query("email_verified"),
+		PhoneVerified: ctx.Query("phone_verified"),
+		SortBy:        ctx.Query("sort_by"),
+		SortOrder:     ctx.Query("sort_order"),
+		Cursor:        ctx.Query("cursor"),
+		Limit:         cast.ToInt32(ctx.Query("limit")),
+	}
+	for _, t := range []string{req.CreatedFrom, req.CreatedTo} {
+		if _, err := time.Parse(time.RFC3339, t); t != "" && err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"Error": h.t(ctx, "created_from and created_to must be RFC3339 timestamps"),
+			})
+			return
+		}
+	}
+
+	resp, err := h.UserRepo.SearchUsers(req)
+	if errors.Is(err, postgres.ErrInvalidFilter) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error searching users", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetUserDetailsHandler returns the full record of a user
+// @Summary Get user details
+// @Description User with profile, roles, restaurant memberships and active sessions
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Success 200 {object} auth_service.UserDetails
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id} [get]
+func (h *Handler) GetUserDetailsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling GetUserDetailsHandler request")
+
+	resp, err := h.userDetails(ctx.Param("user-id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "User not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting user details", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (h *Handler) userDetails(userId string) (*pb.UserDetails, error) {
+	user, err := h.UserRepo.GetUserSummary(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.UserDetails{User: user}
+	resp.Profile, err = h.UserRepo.GetUserProfileById(userId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	sessions, err := h.SessionRepo.ListSessions(&pb.ListSessionsRequest{UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+	resp.Sessions = sessions.Sessions
+
+	resp.Restaurants, err = h.RestaurantRepo.ListMemberships(userId)
+	return resp, err
+}
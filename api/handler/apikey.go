@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/apikey"
+	pb "auth-service/generated/auth_service"
+	"auth-service/models"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAPIKeyHandler creates an API key for a restaurant
+// @Summary Create API key
+// @Description Create a long-lived key for POS systems and partners. The key is shown only once.
+// @Tags API Keys
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param restaurant-id path string true "Restaurant ID"
+// @Param key body auth_service.CreateAPIKeyRequest true "API key"
+// @Success 201 {object} auth_service.CreateAPIKeyResponse
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /restaurants/{restaurant-id}/api-keys [post]
+func (h *Handler) CreateAPIKeyHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling CreateAPIKeyHandler request")
+
+	req := pb.CreateAPIKeyRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	req.RestaurantId = ctx.Param("restaurant-id")
+	req.Name = strings.TrimSpace(req.Name)
+
+	if msg := h.validateAPIKey(ctx, req.Name, req.Permissions); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": msg,
+		})
+		return
+	}
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.Parse(time.RFC3339, req.ExpiresAt)
+		if err != nil || expiresAt.Before(time.Now()) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"Error": h.t(ctx, "expires_at must be a future RFC3339 timestamp"),
+			})
+			return
+		}
+	}
+
+	key, prefix, hash, err := apikey.Generate()
+	if err != nil {
+		h.Logger.Error("Error generating API key", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to create API key")})
+		return
+	}
+
+	stored, err := h.APIKeyRepo.CreateAPIKey(&req, prefix, hash, ctx.GetString("user_id"))
+	if err != nil {
+		h.Logger.Error("Error creating API key", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to create API key")})
+		return
+	}
+
+	h.audit(ctx, audit.ActionAPIKeyCreate, "api_key", stored.Id, nil, gin.H{
+		"restaurant_id": stored.RestaurantId,
+		"name":          stored.Name,
+		"permissions":   stored.Permissions,
+	})
+
+	ctx.JSON(http.StatusCreated, &pb.CreateAPIKeyResponse{
+		ApiKey: stored,
+		Key:    key,
+	})
+}
+
+// ListAPIKeysHandler lists active API keys of a restaurant
+// @Summary List API keys
+// @Description List active keys of a restaurant without the secret part
+// @Tags API Keys
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param restaurant-id path string true "Restaurant ID"
+// @Success 200 {object} auth_service.ListAPIKeysResponse
+// @Failure 403 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /restaurants/{restaurant-id}/api-keys [get]
+func (h *Handler) ListAPIKeysHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ListAPIKeysHandler request")
+
+	resp, err := h.APIKeyRepo.ListAPIKeys(ctx.Param("restaurant-id"))
+	if err != nil {
+		h.Logger.Error("Error listing API keys", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetAPIKeyHandler returns one API key of a restaurant
+// @Summary Get API key
+// @Description Get an active key without the secret part
+// @Tags API Keys
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param restaurant-id path string true "Restaurant ID"
+// @Param id path string true "API key ID"
+// @Success 200 {object} auth_service.APIKey
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /restaurants/{restaurant-id}/api-keys/{id} [get]
+func (h *Handler) GetAPIKeyHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling GetAPIKeyHandler request")
+
+	resp, err := h.APIKeyRepo.GetAPIKey(ctx.Param("restaurant-id"), ctx.Param("id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "API key not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting API key", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdateAPIKeyHandler renames a key or changes its permissions
+// @Summary Update API key
+// @Description Change the name or permissions of an active key
+// @Tags API Keys
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param restaurant-id path string true "Restaurant ID"
+// @Param id path string true "API key ID"
+// @Param key body auth_service.UpdateAPIKeyRequest true "API key"
+// @Success 200 {object} auth_service.APIKey
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /restaurants/{restaurant-id}/api-keys/{id} [put]
+func (h *Handler) UpdateAPIKeyHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling UpdateAPIKeyHandler request")
+
+	req := pb.UpdateAPIKeyRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	req.Id = ctx.Param("id")
+	req.RestaurantId = ctx.Param("restaurant-id")
+	req.Name = strings.TrimSpace(req.Name)
+
+	if msg := h.validateAPIKey(ctx, req.Name, req.Permissions); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": msg,
+		})
+		return
+	}
+
+	before, err := h.APIKeyRepo.GetAPIKey(req.RestaurantId, req.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "API key not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting API key", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	resp, err := h.APIKeyRepo.UpdateAPIKey(&req)
+	if err != nil {
+		h.Logger.Error("Error updating API key", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	h.audit(ctx, audit.ActionAPIKeyUpdate, "api_key", resp.Id,
+		gin.H{"name": before.Name, "permissions": before.Permissions},
+		gin.H{"name": resp.Name, "permissions": resp.Permissions})
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RevokeAPIKeyHandler revokes an API key
+// @Summary Revoke API key
+// @Description Revoke a key immediately
+// @Tags API Keys
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param restaurant-id path string true "Restaurant ID"
+// @Param id path string true "API key ID"
+// @Success 200 {object} models.Success
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /restaurants/{restaurant-id}/api-keys/{id} [delete]
+func (h *Handler) RevokeAPIKeyHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling RevokeAPIKeyHandler request")
+
+	id := ctx.Param("id")
+	err := h.APIKeyRepo.RevokeAPIKey(ctx.Param("restaurant-id"), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "API key not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error revoking API key", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	h.audit(ctx, audit.ActionAPIKeyRevoke, "api_key", id, nil, nil)
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, "API key revoked successfully"),
+	})
+}
+
+// SetRestaurantMemberHandler adds a user to a restaurant
+// @Summary Set restaurant member
+// @Description Make a user an owner or staff member of a restaurant
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param restaurant-id path string true "Restaurant ID"
+// @Param user-id path string true "User ID"
+// @Param member body auth_service.SetRestaurantMemberRequest true "Member"
+// @Success 200 {object} models.Success
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/restaurants/{restaurant-id}/members/{user-id} [put]
+func (h *Handler) SetRestaurantMemberHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling SetRestaurantMemberHandler request")
+
+	req := pb.SetRestaurantMemberRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	req.RestaurantId = ctx.Param("restaurant-id")
+	req.UserId = ctx.Param("user-id")
+
+	if req.Role != postgres.RestaurantRoleOwner && req.Role != postgres.RestaurantRoleStaff {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Role must be owner or staff"),
+		})
+		return
+	}
+
+	if err := h.RestaurantRepo.SetMember(req.RestaurantId, req.UserId, req.Role); err != nil {
+		h.Logger.Error("Error setting restaurant member", "error", err.Error())
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	h.audit(ctx, audit.ActionRestaurantMember, "user", req.UserId, nil, gin.H{
+		"restaurant_id": req.RestaurantId,
+		"role":          req.Role,
+	})
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, "Restaurant member updated successfully"),
+	})
+}
+
+// RemoveRestaurantMemberHandler removes a user from a restaurant
+// @Summary Remove restaurant member
+// @Description Remove a user from a restaurant
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param restaurant-id path string true "Restaurant ID"
+// @Param user-id path string true "User ID"
+// @Success 200 {object} models.Success
+// @Failure 403 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/restaurants/{restaurant-id}/members/{user-id} [delete]
+func (h *Handler) RemoveRestaurantMemberHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling RemoveRestaurantMemberHandler request")
+
+	restaurantId, userId := ctx.Param("restaurant-id"), ctx.Param("user-id")
+	if err := h.RestaurantRepo.RemoveMember(restaurantId, userId); err != nil {
+		h.Logger.Error("Error removing restaurant member", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	h.audit(ctx, audit.ActionRestaurantMember, "user", userId, gin.H{"restaurant_id": restaurantId}, nil)
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, "Restaurant member removed successfully"),
+	})
+}
+
+// PartnerWhoAmIHandler describes the calling API key
+// @Summary Check API key
+// @Description Lets POS systems verify their key, restaurant and permissions
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param X-API-Key header string true "API key"
+// @Success 200 {object} models.APIKeyPrincipal
+// @Failure 401 {object} models.Errors
+// @Router /partner/whoami [get]
+func (h *Handler) PartnerWhoAmIHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, &models.APIKeyPrincipal{
+		APIKeyId:     ctx.GetString("api_key_id"),
+		RestaurantId: ctx.GetString("restaurant_id"),
+		Permissions:  ctx.GetStringSlice("permissions"),
+	})
+}
+
+// validateAPIKey xato bo'lsa tarjima qilingan xabarni qaytaradi
+func (h *Handler) validateAPIKey(ctx *gin.Context, name string, permissions []string) string {
+	if name == "" || len(name) > 100 {
+		return h.t(ctx, "Name is required and must be at most 100 characters")
+	}
+	if !apikey.ValidPermissions(permissions) {
+		return h.t(ctx, "Permissions must be a non-empty subset of %s", strings.Join(apikey.Permissions, ", "))
+	}
+	return ""
+}
@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"auth-service/audit"
+	pb "auth-service/generated/auth_service"
+	"auth-service/models"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// audit amalni audit jurnaliga yozadi. before va after dan faqat o'zgargan
+// maydonlar saqlanadi.
+func (h *Handler) audit(ctx *gin.Context, action, targetType, targetId string, before, after interface{}) {
+	h.auditAs(ctx, auditActor(ctx), action, targetType, targetId, before, after)
+}
+
+// auditActor amalni bajargan odam. Impersonatsiyada support xodimi.
+func auditActor(ctx *gin.Context) string {
+	if id := ctx.GetString("actor_id"); id != "" {
+		return id
+	}
+	return ctx.GetString("user_id")
+}
+
+// auditAs tokensiz so'rovlar uchun actor ni aniq ko'rsatib yozadi
+func (h *Handler) auditAs(ctx *gin.Context, actorId, action, targetType, targetId string, before, after interface{}) {
+	b, a, err := audit.Diff(before, after)
+	if err != nil {
+		h.Logger.Error("Error building audit diff", "error", err.Error())
+		return
+	}
+
+	err = h.AuditRepo.Append(&audit.Entry{
+		ActorId:    actorId,
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetId,
+		Before:     b,
+		After:      a,
+		RequestId:  ctx.GetString("request_id"),
+	})
+	if err != nil {
+		h.Logger.Error("Error writing audit log", "action", action, "error", err.Error())
+	}
+}
+
+// AdminAuditLogsHandler browses the audit log
+// @Summary Browse audit log
+// @Description Filter audit log entries by actor, action, target and time range
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param actor_id query string false "Actor ID"
+// @Param action query string false "Action"
+// @Param target_type query string false "Target type"
+// @Param target_id query string false "Target ID"
+// @Param from query string false "From (RFC3339)"
+// @Param to query string false "To (RFC3339)"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} auth_service.ListAuditLogsResponse
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/audit-logs [get]
+func (h *Handler) AdminAuditLogsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminAuditLogsHandler request")
+
+	filter := &pb.ListAuditLogsRequest{
+		ActorId:    ctx.Query("actor_id"),
+		Action:     ctx.Query("action"),
+		TargetType: ctx.Query("target_type"),
+		TargetId:   ctx.Query("target_id"),
+		From:       ctx.Query("from"),
+		To:         ctx.Query("to"),
+		Limit:      cast.ToInt32(ctx.Query("limit")),
+		Offset:     cast.ToInt32(ctx.Query("offset")),
+	}
+	for _, t := range []string{filter.From, filter.To} {
+		if _, err := time.Parse(time.RFC3339, t); t != "" && err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"Error": h.t(ctx, "from and to must be RFC3339 timestamps"),
+			})
+			return
+		}
+	}
+
+	resp, err := h.AuditRepo.ListAuditLogs(filter)
+	if err != nil {
+		h.Logger.Error("Error listing audit logs", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// AdminVerifyAuditLogHandler checks the audit log hash chain
+// @Summary Verify audit log
+// @Description Recompute the hash chain and report the first tampered entry
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.VerifyAuditLogResponse
+// @Failure 403 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/audit-logs/verify [get]
+func (h *Handler) AdminVerifyAuditLogHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminVerifyAuditLogHandler request")
+
+	resp, err := h.AuditRepo.Verify()
+	if err != nil {
+		h.Logger.Error("Error verifying audit log", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SetUserRoleHandler grants a role to a user
+// @Summary Set user role
+// @Description Grant or revoke the admin role
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Param role body auth_service.SetUserRoleRequest true "Role"
+// @Success 200 {object} auth_service.SetUserRoleResponse
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Router /admin/users/{user-id}/role [put]
+func (h *Handler) SetUserRoleHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling SetUserRoleHandler request")
+
+	req := pb.SetUserRoleRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	req.UserId = ctx.Param("user-id")
+
+	if req.Role != models.RoleUser && req.Role != models.RoleAdmin {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Unknown role"),
+		})
+		return
+	}
+
+	oldRole, err := h.UserRepo.SetUserRole(req.UserId, req.Role)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "User not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error setting user role", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	h.audit(ctx, audit.ActionRoleGrant, "user", req.UserId, gin.H{"role": oldRole}, gin.H{"role": req.Role})
+
+	ctx.JSON(http.StatusOK, &pb.SetUserRoleResponse{
+		Message: h.t(ctx, "User role updated successfully"),
+	})
+}
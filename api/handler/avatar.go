@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/avatar"
+	"auth-service/models"
+	"auth-service/profile"
+	"auth-service/storage/blob"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UploadAvatarHandler replaces the current user's avatar
+// @Summary Upload my avatar
+// @Description Upload a JPEG, PNG or GIF (up to AVATAR_MAX_BYTES, default 5 MB) as multipart field "avatar". It is cropped to a square, stripped of EXIF and stored in 64, 256 and 512 px variants.
+// @Tags Auth
+// @Accept multipart/form-data
+// @Security ApiKeyAuth
+// @Produce json
+// @Param avatar formData file true "Image"
+// @Success 200 {object} models.Avatar
+// @Failure 400 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 413 {object} models.Errors
+// @Failure 415 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/avatar [put]
+func (h *Handler) UploadAvatarHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling UploadAvatarHandler request")
+
+	maxBytes := h.Config.AVATAR_MAX_BYTES
+	// Multipart sarlavhalari uchun biroz zaxira
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBytes+64<<10)
+
+	header, err := ctx.FormFile("avatar")
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) || (err == nil && header.Size > maxBytes) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"Error": h.t(ctx, "Avatar must be at most %d MB", maxBytes>>20),
+		})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Multipart field \"avatar\" is required"),
+		})
+		return
+	}
+
+	f, err := header.Open()
+	if err != nil {
+		h.Logger.Error("Error opening avatar upload", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to upload avatar")})
+		return
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxBytes))
+	if err != nil {
+		h.Logger.Error("Error reading avatar upload", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to upload avatar")})
+		return
+	}
+
+	variants, err := avatar.Process(data)
+	if errors.Is(err, avatar.ErrUnsupportedType) {
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	if errors.Is(err, avatar.ErrInvalidImage) || errors.Is(err, avatar.ErrDimensions) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error processing avatar", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to upload avatar")})
+		return
+	}
+
+	userId := ctx.GetString("user_id")
+	version, err := avatarVersion()
+	if err != nil {
+		h.Logger.Error("Error generating avatar version", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to upload avatar")})
+		return
+	}
+	prefix := avatar.Prefix(userId, version)
+
+	resp := &models.Avatar{Variants: map[string]string{}}
+	for _, v := range variants {
+		key := avatar.Key(userId, version, v.Size)
+		if err := h.Blobs.Put(ctx, key, v.Data, "image/jpeg"); err != nil {
+			h.Logger.Error("Error storing avatar", "error", err.Error())
+			h.deleteBlobs(ctx, prefix)
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to upload avatar")})
+			return
+		}
+		resp.Variants[strconv.Itoa(v.Size)] = h.Blobs.URL(key)
+	}
+	resp.AvatarUrl = h.Blobs.URL(avatar.Key(userId, version, avatar.DefaultSize))
+
+	old, err := h.UserRepo.SetAvatar(userId, prefix, resp.AvatarUrl, profileChange(ctx, profile.ChannelAPI))
+	if errors.Is(err, sql.ErrNoRows) {
+		h.deleteBlobs(ctx, prefix)
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Profile not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error saving avatar", "error", err.Error())
+		h.deleteBlobs(ctx, prefix)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to upload avatar")})
+		return
+	}
+	if old != "" {
+		h.deleteBlobs(ctx, old)
+	}
+
+	h.audit(ctx, audit.ActionProfileUpdate, "user", userId, gin.H{"avatar": old}, gin.H{"avatar": prefix})
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteAvatarHandler removes the current user's avatar
+// @Summary Delete my avatar
+// @Description Remove the avatar and all its variants
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} models.Success
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/avatar [delete]
+func (h *Handler) DeleteAvatarHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling DeleteAvatarHandler request")
+
+	userId := ctx.GetString("user_id")
+	old, err := h.UserRepo.SetAvatar(userId, "", "", profileChange(ctx, profile.ChannelAPI))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Profile not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error removing avatar", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to delete avatar")})
+		return
+	}
+
+	if old != "" {
+		h.deleteBlobs(ctx, old)
+		h.audit(ctx, audit.ActionProfileUpdate, "user", userId, gin.H{"avatar": old}, gin.H{"avatar": ""})
+	}
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, "Avatar deleted successfully"),
+	})
+}
+
+// ServeMediaHandler serves stored avatar images
+// @Summary Get media file
+// @Description Public, immutable avatar images referenced by avatar_url
+// @Tags Media
+// @Produce jpeg
+// @Param key path string true "Blob key, e.g. avatars/{user-id}/{version}_256.jpg"
+// @Success 200 {file} binary
+// @Failure 404 {object} models.Errors
+// @Router /media/{key} [get]
+func (h *Handler) ServeMediaHandler(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+	if !strings.HasPrefix(key, "avatars/") {
+		ctx.JSON(http.StatusNotFound, gin.H{"Error": h.t(ctx, "Not found")})
+		return
+	}
+
+	r, err := h.Blobs.Open(ctx, key)
+	if errors.Is(err, blob.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"Error": h.t(ctx, "Not found")})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error opening media", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to load media")})
+		return
+	}
+	defer r.Close()
+
+	// Har bir yuklash yangi versiya kalitini oladi, shuning uchun fayl o'zgarmaydi
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.DataFromReader(http.StatusOK, -1, "image/jpeg", r, nil)
+}
+
+// deleteBlobs fayllarni o'chiradi, xato faqat logga yoziladi
+func (h *Handler) deleteBlobs(ctx *gin.Context, prefix string) {
+	if err := h.Blobs.DeletePrefix(ctx, prefix); err != nil {
+		h.Logger.Error("Error deleting blobs", "prefix", prefix, "error", err.Error())
+	}
+}
+
+func avatarVersion() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
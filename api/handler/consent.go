@@ -0,0 +1,329 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/token"
+	"auth-service/consent"
+	pb "auth-service/generated/auth_service"
+	"auth-service/models"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const consentChallengeTTL = 30 * time.Minute
+
+func documentIds(docs []*models.LegalDocument) []string {
+	ids := make([]string, 0, len(docs))
+	for _, d := range docs {
+		ids = append(ids, d.Id)
+	}
+	return ids
+}
+
+// requireRegistrationConsent ro'yxatdan o'tishda amaldagi barcha hujjatlar
+// qabul qilinganini tekshiradi. Yo'q bo'lsa javobni yozib false qaytaradi.
+func (h *Handler) requireRegistrationConsent(ctx *gin.Context, accepted []string) bool {
+	current, err := h.ConsentRepo.CurrentLegalDocuments()
+	if err != nil {
+		h.Logger.Error("Error getting legal documents", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to check consent")})
+		return false
+	}
+
+	if len(consent.Missing(documentIds(current), accepted)) > 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"error":     h.t(ctx, "You must accept the current terms of service and privacy policy"),
+			"code":      consent.Required,
+			"documents": current,
+		})
+		return false
+	}
+	return true
+}
+
+// requireLoginConsent yangi hujjat versiyalari qabul qilinmagan bo'lsa
+// token bermasdan consent_required challenge qaytaradi. Javob yozilgan
+// bo'lsa true.
+func (h *Handler) requireLoginConsent(ctx *gin.Context, user *pb.LoginResponse, deviceName string) bool {
+	pending, err := h.ConsentRepo.PendingLegalDocuments(user.UserId)
+	if err != nil {
+		h.Logger.Error("Error getting pending legal documents", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to check consent")})
+		return true
+	}
+	if len(pending) == 0 {
+		return false
+	}
+
+	plain, hash, err := token.GenerateOpaque()
+	if err != nil {
+		h.Logger.Error("Error generating consent token", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to check consent")})
+		return true
+	}
+
+	id, err := h.LoginAlertRepo.CreateLoginChallenge(postgres.ChallengeConsent, user.UserId, deviceName, hash, consentChallengeTTL)
+	if err != nil {
+		h.Logger.Error("Error creating consent challenge", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to check consent")})
+		return true
+	}
+
+	ctx.JSON(http.StatusAccepted, &models.ConsentChallenge{
+		ChallengeId: id,
+		Token:       plain,
+		Message:     consent.Required,
+		Documents:   pending,
+	})
+	return true
+}
+
+// LoginConsentHandler accepts new legal documents and continues the login
+// @Summary Accept terms to log in
+// @Description Accept the documents returned with a consent_required login response and continue the login. Any document still not accepted returns a new consent_required response.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Consent body models.LoginConsentRequest true "Challenge, token and accepted document ids"
+// @Success 200 {object} models.Token
+// @Success 202 {object} models.ConsentChallenge
+// @Failure 400 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/login/consent [post]
+func (h *Handler) LoginConsentHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling LoginConsentHandler request")
+
+	req := models.LoginConsentRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	challenge, err := h.LoginAlertRepo.VerifyLoginChallenge(postgres.ChallengeConsent, req.ChallengeId, token.HashOpaque(req.Token))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Invalid or expired consent token"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error verifying consent challenge", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to record consent")})
+		return
+	}
+
+	err = h.ConsentRepo.AcceptLegalDocuments(challenge.UserId, req.DocumentIds, ctx.ClientIP(), ctx.Request.UserAgent())
+	if err != nil {
+		h.Logger.Error("Error accepting legal documents", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to record consent")})
+		return
+	}
+
+	user, err := h.UserRepo.GetUserById(challenge.UserId)
+	if err != nil {
+		h.Logger.Error("Error getting user", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to record consent")})
+		return
+	}
+
+	// Qurilma tekshiruvi va step-up consent dan keyin
+	h.loginFromDevice(ctx, user, challenge.DeviceName, user.Email)
+}
+
+// LegalDocumentsHandler lists the legal documents in force
+// @Summary Current legal documents
+// @Description The latest terms of service and privacy policy. Their ids go into accepted_documents at registration.
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} models.LegalDocuments
+// @Failure 500 {object} models.Errors
+// @Router /auth/legal-documents [get]
+func (h *Handler) LegalDocumentsHandler(ctx *gin.Context) {
+	docs, err := h.ConsentRepo.CurrentLegalDocuments()
+	if err != nil {
+		h.Logger.Error("Error getting legal documents", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to get legal documents")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &models.LegalDocuments{Documents: docs})
+}
+
+// GetMyConsentsHandler returns the current user's accepted and pending documents
+// @Summary Get my consents
+// @Description Document versions the user accepted, with time and IP, and the current ones still waiting for acceptance
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} models.MyConsents
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/consents [get]
+func (h *Handler) GetMyConsentsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling GetMyConsentsHandler request")
+
+	h.myConsents(ctx, ctx.GetString("user_id"))
+}
+
+func (h *Handler) myConsents(ctx *gin.Context, userId string) {
+	accepted, err := h.ConsentRepo.ListConsents(userId)
+	if err != nil {
+		h.Logger.Error("Error listing consents", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to get consents")})
+		return
+	}
+
+	pending, err := h.ConsentRepo.PendingLegalDocuments(userId)
+	if err != nil {
+		h.Logger.Error("Error getting pending legal documents", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to get consents")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &models.MyConsents{
+		Accepted: accepted,
+		Pending:  pending,
+	})
+}
+
+// AcceptConsentHandler records acceptance of current legal documents
+// @Summary Accept legal documents
+// @Description Accept current document versions without logging in again. Ids of old versions are ignored.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param Consent body models.AcceptConsentRequest true "Accepted document ids"
+// @Success 200 {object} models.MyConsents
+// @Failure 400 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/consents [post]
+func (h *Handler) AcceptConsentHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AcceptConsentHandler request")
+
+	req := models.AcceptConsentRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	userId := ctx.GetString("user_id")
+
+	err := h.ConsentRepo.AcceptLegalDocuments(userId, req.DocumentIds, ctx.ClientIP(), ctx.Request.UserAgent())
+	if err != nil {
+		h.Logger.Error("Error accepting legal documents", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to record consent")})
+		return
+	}
+
+	h.myConsents(ctx, userId)
+}
+
+// AdminPublishLegalDocumentHandler publishes a new legal document version
+// @Summary Publish legal document
+// @Description Publish a new version of the terms of service or privacy policy. It takes effect at once and every user must accept it at their next login.
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param document body models.PublishLegalDocumentRequest true "Type, version and https URL of the text"
+// @Success 201 {object} models.LegalDocument
+// @Failure 400 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/legal-documents [post]
+func (h *Handler) AdminPublishLegalDocumentHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminPublishLegalDocumentHandler request")
+
+	req := models.PublishLegalDocumentRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	doc := &models.LegalDocument{
+		Type:    strings.TrimSpace(req.Type),
+		Version: strings.TrimSpace(req.Version),
+		Url:     strings.TrimSpace(req.Url),
+	}
+	if !consent.ValidType(doc.Type) || !consent.ValidVersion(doc.Version) || !consent.ValidURL(doc.Url) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "type must be terms_of_service or privacy_policy, version up to 20 letters, digits, '.' or '-', and url an https link"),
+		})
+		return
+	}
+
+	err := h.ConsentRepo.PublishLegalDocument(doc, ctx.GetString("user_id"))
+	if errors.Is(err, postgres.ErrDocumentVersionExists) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, "This document version is already published"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error publishing legal document", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to publish legal document")})
+		return
+	}
+
+	h.audit(ctx, audit.ActionLegalDocumentPublish, "legal_document", doc.Id, nil, doc)
+
+	ctx.JSON(http.StatusCreated, doc)
+}
+
+// AdminListLegalDocumentsHandler lists every legal document version
+// @Summary List legal documents
+// @Description All published versions, newest first
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} models.LegalDocuments
+// @Failure 500 {object} models.Errors
+// @Router /admin/legal-documents [get]
+func (h *Handler) AdminListLegalDocumentsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminListLegalDocumentsHandler request")
+
+	docs, err := h.ConsentRepo.ListLegalDocuments()
+	if err != nil {
+		h.Logger.Error("Error listing legal documents", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to get legal documents")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &models.LegalDocuments{Documents: docs})
+}
+
+// AdminConsentReportHandler reports acceptance rates per document version
+// @Summary Consent report
+// @Description For each document version, how many of the accounts that are not deleted have accepted it
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} models.ConsentReport
+// @Failure 500 {object} models.Errors
+// @Router /admin/legal-documents/report [get]
+func (h *Handler) AdminConsentReportHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminConsentReportHandler request")
+
+	report, err := h.ConsentRepo.ConsentReport()
+	if err != nil {
+		h.Logger.Error("Error building consent report", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to build consent report")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/lifecycle"
+	pb "auth-service/generated/auth_service"
+	"auth-service/i18n"
+	"auth-service/models"
+	"auth-service/notify"
+	"auth-service/storage/postgres"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// recentLoginWindow shu vaqt ichida ochilgan sessiya bilan parol so'ralmaydi
+const recentLoginWindow = 10 * time.Minute
+
+// DeleteAccountHandler schedules deletion of the current user's account
+// @Summary Delete my account
+// @Description Schedule deletion after the grace period and sign out everywhere. Requires the password unless the session was opened in the last 10 minutes.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param delete body models.DeleteAccountRequest false "Password"
+// @Success 200 {object} models.AccountDeletionScheduled
+// @Failure 401 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me [delete]
+func (h *Handler) DeleteAccountHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling DeleteAccountHandler request")
+
+	req := models.DeleteAccountRequest{}
+	_ = ctx.ShouldBindJSON(&req)
+
+	userId := ctx.GetString("user_id")
+	if req.Password != "" {
+		hash, err := h.UserRepo.GetPasswordHash(userId)
+		if err != nil {
+			h.Logger.Error("Error getting password", "error", err.Error())
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to delete account")})
+			return
+		}
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(req.Password)) != nil {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": h.t(ctx, "Invalid password")})
+			return
+		}
+	} else {
+		recent, err := h.SessionRepo.OpenedWithin(ctx.GetString("session_id"), recentLoginWindow)
+		if err != nil {
+			h.Logger.Error("Error checking session age", "error", err.Error())
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to delete account")})
+			return
+		}
+		if !recent {
+			ctx.JSON(http.StatusUnauthorized, gin.H{"error": h.t(ctx, "Password or a recent login is required")})
+			return
+		}
+	}
+
+	deleteTime := time.Now().UTC().Add(time.Duration(h.Config.DELETION_GRACE_DAYS) * 24 * time.Hour)
+	deleteAt := deleteTime.Format(time.RFC3339)
+	before, err := h.UserRepo.SetStatus(userId, lifecycle.StatusPendingDeletion, "requested by user", deleteAt)
+	if errors.Is(err, postgres.ErrInvalidTransition) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, "Account cannot be deleted while %s", before.Status),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error scheduling account deletion", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to delete account")})
+		return
+	}
+
+	if _, err := h.SessionRepo.RevokeOtherSessions(userId, ""); err != nil {
+		h.Logger.Error("Error revoking sessions", "error", err.Error())
+	}
+
+	h.audit(ctx, audit.ActionStatusChange, "user", userId,
+		gin.H{"status": before.Status},
+		gin.H{"status": lifecycle.StatusPendingDeletion, "delete_at": deleteAt})
+
+	h.notify(notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      ctx.GetString("user_email"),
+		Subject: h.t(ctx, "Your DineReserve account will be deleted"),
+		Body: h.t(ctx, "Your account and profile will be permanently deleted on %s. "+
+			"If you change your mind, sign in before then and choose to restore your account.",
+			i18n.FormatTime(deleteTime, ctx.GetString("timezone"))),
+	})
+
+	ctx.JSON(http.StatusOK, &models.AccountDeletionScheduled{
+		Message:  h.t(ctx, "Account scheduled for deletion"),
+		DeleteAt: deleteAt,
+	})
+}
+
+// RestoreAccountHandler cancels a scheduled deletion and logs the user in
+// @Summary Restore my account
+// @Description Cancel a scheduled deletion during the grace period. Takes the same body as login.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Login body auth_service.LoginRequest true "User Login"
+// @Param X-Device-ID header string false "Stable device identifier sent by mobile apps"
+// @Success 200 {object} models.Token
+// @Success 202 {object} models.StepUpChallenge
+// @Failure 400 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/restore [post]
+func (h *Handler) RestoreAccountHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling RestoreAccountHandler request")
+
+	user := pb.LoginRequest{}
+	if err := ctx.ShouldBindJSON(&user); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	storedUser, login := h.checkPassword(ctx, &user)
+	if storedUser == nil {
+		return
+	}
+
+	if storedUser.Status != lifecycle.StatusPendingDeletion {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, "Account is not scheduled for deletion"),
+		})
+		return
+	}
+
+	before, err := h.UserRepo.SetStatus(storedUser.UserId, lifecycle.StatusActive, "restored by user", "")
+	if errors.Is(err, postgres.ErrInvalidTransition) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, "Account is not scheduled for deletion"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error restoring account", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to restore account")})
+		return
+	}
+
+	h.auditAs(ctx, storedUser.UserId, audit.ActionStatusChange, "user", storedUser.UserId,
+		gin.H{"status": before.Status},
+		gin.H{"status": lifecycle.StatusActive, "reason": "restored by user"})
+
+	storedUser.Status = lifecycle.StatusActive
+	h.loginFromDevice(ctx, storedUser, user.DeviceName, login)
+}
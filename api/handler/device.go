@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/device"
+	"auth-service/auth/token"
+	pb "auth-service/generated/auth_service"
+	"auth-service/i18n"
+	"auth-service/models"
+	"auth-service/notify"
+	"auth-service/storage/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	loginAlertTTL     = 7 * 24 * time.Hour
+	loginChallengeTTL = 10 * time.Minute
+)
+
+// notify xabarni fonda yuboradi, so'rov javobini kutib qolmasligi uchun
+func (h *Handler) notify(msg notify.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		if err := h.Notifier.Send(ctx, msg); err != nil {
+			h.Logger.Error("Error sending notification", "channel", msg.Channel, "error", err.Error())
+		}
+	}()
+}
+
+// sendLoginAlert yangi qurilmadan kirish haqida email va (telefon bo'lsa)
+// SMS yuboradi
+func (h *Handler) sendLoginAlert(ctx *gin.Context, user *pb.LoginResponse, deviceName, fingerprint string) {
+	plain, hash, err := token.GenerateOpaque()
+	if err != nil {
+		h.Logger.Error("Error generating login alert token", "error", err.Error())
+		return
+	}
+
+	err = h.LoginAlertRepo.CreateLoginAlert(&postgres.LoginAlert{
+		UserId:      user.UserId,
+		SessionId:   user.SessionId,
+		Fingerprint: fingerprint,
+	}, hash, loginAlertTTL)
+	if err != nil {
+		h.Logger.Error("Error creating login alert", "error", err.Error())
+		return
+	}
+
+	if deviceName == "" {
+		deviceName = ctx.Request.UserAgent()
+	}
+	link := fmt.Sprintf("%s/security/not-me?token=%s", h.Config.APP_URL, url.QueryEscape(plain))
+	locale := i18n.Resolve(user.Locale, ctx.GetString("locale"))
+	text := i18n.T(locale, "New sign-in to your DineReserve account from %s (IP %s) at %s.\n\nIf this wasn't you, open this link to sign out that device and set a new password:\n%s",
+		deviceName, ctx.ClientIP(), i18n.FormatTime(time.Now(), user.Timezone), link)
+
+	h.notify(notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      user.Email,
+		Subject: i18n.T(locale, "New sign-in to your DineReserve account"),
+		Body:    text,
+	})
+
+	profile, err := h.UserRepo.GetUserProfileById(user.UserId)
+	if err == nil && profile.PhoneNumber != "" {
+		h.notify(notify.Message{
+			Channel: notify.ChannelSMS,
+			To:      profile.PhoneNumber,
+			Body:    i18n.T(locale, "DineReserve: new sign-in from %s. Not you? %s", deviceName, link),
+		})
+	}
+}
+
+// startStepUp token berishdan oldin emailga bir martalik kod yuboradi
+func (h *Handler) startStepUp(ctx *gin.Context, user *pb.LoginResponse, deviceName string) {
+	code, err := token.GenerateCode()
+	if err != nil {
+		h.Logger.Error("Error generating login code", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to start verification")})
+		return
+	}
+
+	id, err := h.LoginAlertRepo.CreateLoginChallenge(postgres.ChallengeStepUp, user.UserId, deviceName, token.HashOpaque(code), loginChallengeTTL)
+	if err != nil {
+		h.Logger.Error("Error creating login challenge", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to start verification")})
+		return
+	}
+
+	locale := i18n.Resolve(user.Locale, ctx.GetString("locale"))
+	h.notify(notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      user.Email,
+		Subject: i18n.T(locale, "Your DineReserve sign-in code"),
+		Body:    i18n.T(locale, "Someone is signing in to your account from a new device. Your code is %s. It expires in 10 minutes.", code),
+	})
+
+	ctx.JSON(http.StatusAccepted, &models.StepUpChallenge{
+		ChallengeId: id,
+		Message:     "step_up_required",
+	})
+}
+
+// VerifyLoginHandler completes a login that required a step-up code
+// @Summary Verify login code
+// @Description Finish a login from a new device with the code sent by email
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param X-Device-ID header string false "Stable device identifier sent by mobile apps"
+// @Param Verify body models.VerifyLoginRequest true "Challenge"
+// @Success 200 {object} models.Token
+// @Failure 400 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/login/verify [post]
+func (h *Handler) VerifyLoginHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling VerifyLoginHandler request")
+
+	req := models.VerifyLoginRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	challenge, err := h.LoginAlertRepo.VerifyLoginChallenge(postgres.ChallengeStepUp, req.ChallengeId, token.HashOpaque(req.Code))
+	if errors.Is(err, sql.ErrNoRows) {
+		h.recordLogin(ctx, postgres.LoginMethodOTP, "", req.ChallengeId, postgres.LoginFailureInvalidCode)
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Invalid or expired code"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error verifying login challenge", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to verify code")})
+		return
+	}
+
+	user, err := h.UserRepo.GetUserById(challenge.UserId)
+	if err != nil {
+		h.Logger.Error("Error getting user", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to verify code")})
+		return
+	}
+
+	h.completeLogin(ctx, user, challenge.DeviceName, postgres.LoginMethodOTP, false)
+}
+
+// DenyLoginHandler handles the "this wasn't me" link from a login alert
+// @Summary Deny a login
+// @Description Revoke all sessions, forget the device and set a new password
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Deny body models.DenyLoginRequest true "Alert token and new password"
+// @Success 200 {object} models.Success
+// @Failure 400 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/login-alerts/deny [post]
+func (h *Handler) DenyLoginHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling DenyLoginHandler request")
+
+	req := models.DenyLoginRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	if len(req.NewPassword) < 8 {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Password must be at least 8 characters"),
+		})
+		return
+	}
+
+	alert, err := h.LoginAlertRepo.UseLoginAlert(token.HashOpaque(req.Token))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Link is invalid or expired"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error using login alert", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to process request")})
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	if err := h.UserRepo.UpdatePassword(alert.UserId, string(hashedPassword)); err != nil {
+		h.Logger.Error("Error updating password", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update password")})
+		return
+	}
+
+	// Bo'sh id bilan hamma sessiyalar, shu jumladan shubhali sessiya ham bekor qilinadi
+	if _, err := h.SessionRepo.RevokeOtherSessions(alert.UserId, ""); err != nil {
+		h.Logger.Error("Error revoking sessions", "error", err.Error())
+	}
+	if err := h.DeviceRepo.ForgetDevice(alert.UserId, alert.Fingerprint); err != nil {
+		h.Logger.Error("Error forgetting device", "error", err.Error())
+	}
+
+	h.auditAs(ctx, alert.UserId, audit.ActionPasswordReset, "user", alert.UserId, nil, gin.H{
+		"reason":     "login_denied",
+		"session_id": alert.SessionId,
+	})
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, "All sessions were signed out and your password was changed"),
+	})
+}
+
+// deviceFingerprint joriy so'rov qurilmasining izi
+func deviceFingerprint(ctx *gin.Context, deviceName string) string {
+	return device.Fingerprint(ctx.Request.UserAgent(), deviceName, ctx.GetHeader("X-Device-ID"))
+}
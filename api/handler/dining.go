@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/dining"
+	pb "auth-service/generated/auth_service"
+	"auth-service/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetDiningPreferencesHandler returns the current user's dining preferences
+// @Summary Get my dining preferences
+// @Description Dietary restrictions, allergies, seating, favourite cuisines and occasions
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.DiningPreferences
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/preferences/dining [get]
+func (h *Handler) GetDiningPreferencesHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling GetDiningPreferencesHandler request")
+
+	h.diningPreferences(ctx, ctx.GetString("user_id"))
+}
+
+// AdminGetDiningPreferencesHandler returns a user's dining preferences
+// @Summary Get dining preferences
+// @Description Dining preferences of any user
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Success 200 {object} auth_service.DiningPreferences
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/preferences/dining [get]
+func (h *Handler) AdminGetDiningPreferencesHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminGetDiningPreferencesHandler request")
+
+	h.diningPreferences(ctx, ctx.Param("user-id"))
+}
+
+func (h *Handler) diningPreferences(ctx *gin.Context, userId string) {
+	resp, err := h.UserRepo.GetDiningPreferences(userId)
+	if err != nil {
+		h.Logger.Error("Error getting dining preferences", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdateDiningPreferencesHandler replaces the current user's dining preferences
+// @Summary Update my dining preferences
+// @Description Replace all dining preferences. Values must come from GET /auth/preferences/dining/vocabulary; occasion dates are MM-DD or YYYY-MM-DD.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param preferences body auth_service.DiningPreferences true "Dining preferences"
+// @Success 200 {object} auth_service.DiningPreferences
+// @Failure 400 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/preferences/dining [put]
+func (h *Handler) UpdateDiningPreferencesHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling UpdateDiningPreferencesHandler request")
+
+	prefs := pb.DiningPreferences{}
+	if err := ctx.ShouldBindJSON(&prefs); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	prefs.UserId = ctx.GetString("user_id")
+
+	if err := dining.Normalize(&prefs); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	before, err := h.UserRepo.GetDiningPreferences(prefs.UserId)
+	if err != nil {
+		h.Logger.Error("Error getting dining preferences", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update dining preferences")})
+		return
+	}
+
+	if err := h.UserRepo.SetDiningPreferences(&prefs); err != nil {
+		h.Logger.Error("Error updating dining preferences", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update dining preferences")})
+		return
+	}
+
+	resp, err := h.UserRepo.GetDiningPreferences(prefs.UserId)
+	if err != nil {
+		h.Logger.Error("Error getting dining preferences", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update dining preferences")})
+		return
+	}
+
+	h.audit(ctx, audit.ActionProfileUpdate, "user", prefs.UserId, before, resp)
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DiningVocabularyHandler lists allowed dining preference values
+// @Summary Dining preference vocabulary
+// @Description Allowed values for each dining preference field
+// @Tags Auth
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} models.DiningVocabulary
+// @Router /auth/preferences/dining/vocabulary [get]
+func (h *Handler) DiningVocabularyHandler(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, &models.DiningVocabulary{
+		DietaryRestrictions: dining.Dietary,
+		Allergies:           dining.Allergies,
+		Seating:             dining.Seating,
+		FavoriteCuisines:    dining.Cuisines,
+		Occasions:           dining.Occasions,
+	})
+}
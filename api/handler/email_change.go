@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/identifier"
+	"auth-service/auth/token"
+	"auth-service/models"
+	"auth-service/notify"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	emailConfirmTTL = 24 * time.Hour
+	emailUndoTTL    = 7 * 24 * time.Hour
+)
+
+// ChangeEmailHandler starts changing the user's email address
+// @Summary Change my email
+// @Description Send a confirmation link to the new address and an undo link to the current one. The address changes only after confirmation.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param email body models.ChangeEmailRequest true "New email and current password"
+// @Success 202 {object} models.Success
+// @Failure 400 {object} models.Errors
+// @Failure 401 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/email [post]
+func (h *Handler) ChangeEmailHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ChangeEmailHandler request")
+
+	req := models.ChangeEmailRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	newEmail := identifier.NormalizeEmail(req.NewEmail)
+	if _, err := mail.ParseAddress(newEmail); err != nil || identifier.Kind(newEmail) != identifier.KindEmail || len(newEmail) > 100 {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "A valid new email is required"),
+		})
+		return
+	}
+
+	userId := ctx.GetString("user_id")
+	user, err := h.UserRepo.GetUserById(userId)
+	if err != nil {
+		h.Logger.Error("Error getting user", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to change email")})
+		return
+	}
+	if newEmail == identifier.NormalizeEmail(user.Email) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "New email is the same as the current one"),
+		})
+		return
+	}
+
+	hash, err := h.UserRepo.GetPasswordHash(userId)
+	if err != nil {
+		h.Logger.Error("Error getting password", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to change email")})
+		return
+	}
+	if bcrypt.CompareHashAndPassword([]byte(hash), []byte(req.Password)) != nil {
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": h.t(ctx, "Invalid password")})
+		return
+	}
+
+	exists, err := h.UserRepo.EmailExists(newEmail)
+	if err != nil {
+		h.Logger.Error("Error checking email", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to change email")})
+		return
+	}
+	if exists {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, "Email is already registered"),
+		})
+		return
+	}
+
+	confirmToken, confirmHash, err := token.GenerateOpaque()
+	if err != nil {
+		h.Logger.Error("Error generating email token", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to change email")})
+		return
+	}
+	undoToken, undoHash, err := token.GenerateOpaque()
+	if err != nil {
+		h.Logger.Error("Error generating email token", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to change email")})
+		return
+	}
+
+	_, err = h.EmailChangeRepo.CreateEmailChange(&postgres.EmailChange{
+		UserId:   userId,
+		OldEmail: user.Email,
+		NewEmail: newEmail,
+	}, confirmHash, undoHash, emailConfirmTTL, emailUndoTTL)
+	if err != nil {
+		h.Logger.Error("Error creating email change", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to change email")})
+		return
+	}
+
+	h.notify(notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      newEmail,
+		Subject: h.t(ctx, "Confirm your new DineReserve email"),
+		Body: h.t(ctx, "Open this link within 24 hours to use this address for your DineReserve account:\n%s/account/confirm-email?token=%s",
+			h.Config.APP_URL, url.QueryEscape(confirmToken)),
+	})
+	h.notify(notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      user.Email,
+		Subject: h.t(ctx, "Your DineReserve email is being changed"),
+		Body: h.t(ctx, "Someone asked to change the email of your DineReserve account to %s. "+
+			"If this wasn't you, open this link to cancel the change and sign out all devices:\n%s/account/undo-email?token=%s",
+			newEmail, h.Config.APP_URL, url.QueryEscape(undoToken)),
+	})
+
+	ctx.JSON(http.StatusAccepted, &models.Success{
+		Message: h.t(ctx, "Confirmation link sent to the new email"),
+	})
+}
+
+// ConfirmEmailChangeHandler applies a pending email change
+// @Summary Confirm email change
+// @Description Confirm the new address with the token from the confirmation link. All sessions are signed out.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token body models.EmailTokenRequest true "Confirmation token"
+// @Success 200 {object} models.Success
+// @Failure 400 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/email/confirm [post]
+func (h *Handler) ConfirmEmailChangeHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ConfirmEmailChangeHandler request")
+
+	req := models.EmailTokenRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	change, err := h.EmailChangeRepo.ConfirmEmailChange(token.HashOpaque(req.Token))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Link is invalid or expired"),
+		})
+		return
+	}
+	if errors.Is(err, postgres.ErrEmailTaken) || errors.Is(err, postgres.ErrEmailChangeStale) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error confirming email change", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to confirm email")})
+		return
+	}
+
+	// Tokenlarda eski email bor, shuning uchun hamma qayta kiradi
+	if _, err := h.SessionRepo.RevokeOtherSessions(change.UserId, ""); err != nil {
+		h.Logger.Error("Error revoking sessions", "error", err.Error())
+	}
+
+	h.auditAs(ctx, change.UserId, audit.ActionEmailChange, "user", change.UserId,
+		gin.H{"email": change.OldEmail}, gin.H{"email": change.NewEmail})
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, "Email changed successfully, please log in again"),
+	})
+}
+
+// UndoEmailChangeHandler cancels or reverts an email change
+// @Summary Undo email change
+// @Description Cancel a pending change or restore the old address with the token sent to it. All sessions are signed out.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token body models.EmailTokenRequest true "Undo token"
+// @Success 200 {object} models.Success
+// @Failure 400 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/email/undo [post]
+func (h *Handler) UndoEmailChangeHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling UndoEmailChangeHandler request")
+
+	req := models.EmailTokenRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	change, err := h.EmailChangeRepo.UndoEmailChange(token.HashOpaque(req.Token))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Link is invalid or expired"),
+		})
+		return
+	}
+	if errors.Is(err, postgres.ErrEmailTaken) || errors.Is(err, postgres.ErrEmailChangeStale) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error undoing email change", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to undo email change")})
+		return
+	}
+
+	if _, err := h.SessionRepo.RevokeOtherSessions(change.UserId, ""); err != nil {
+		h.Logger.Error("Error revoking sessions", "error", err.Error())
+	}
+
+	if change.Reverted {
+		h.auditAs(ctx, change.UserId, audit.ActionEmailChange, "user", change.UserId,
+			gin.H{"email": change.NewEmail}, gin.H{"email": change.OldEmail, "reason": "undo"})
+	}
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, "Email change cancelled and all sessions signed out"),
+	})
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/identifier"
+	"auth-service/auth/lifecycle"
+	"auth-service/auth/token"
+	"auth-service/models"
+	"auth-service/notify"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const emailVerifyTTL = 24 * time.Hour
+
+// sendEmailVerification hisobni faollashtiruvchi havolani yuboradi
+func (h *Handler) sendEmailVerification(ctx *gin.Context, userId, email string) error {
+	verifyToken, verifyHash, err := token.GenerateOpaque()
+	if err != nil {
+		return err
+	}
+	if err := h.UserRepo.CreateEmailVerification(userId, email, verifyHash, emailVerifyTTL); err != nil {
+		return err
+	}
+
+	h.notify(notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      email,
+		Subject: h.t(ctx, "Verify your DineReserve email"),
+		Body: h.t(ctx, "Open this link within 24 hours to activate your DineReserve account:\n%s/account/verify-email?token=%s",
+			h.Config.APP_URL, url.QueryEscape(verifyToken)),
+	})
+	return nil
+}
+
+// VerifyEmailHandler activates a new account
+// @Summary Verify email
+// @Description Verify the email with the token from the link sent at registration. A pending_verification account becomes active.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param token body models.EmailTokenRequest true "Verification token"
+// @Success 200 {object} models.Success
+// @Failure 400 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/email/verify [post]
+func (h *Handler) VerifyEmailHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling VerifyEmailHandler request")
+
+	req := models.EmailTokenRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	userId, err := h.UserRepo.VerifyEmail(token.HashOpaque(req.Token))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Link is invalid or expired"),
+		})
+		return
+	}
+	if errors.Is(err, postgres.ErrEmailChangeStale) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error verifying email", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to verify email")})
+		return
+	}
+
+	h.auditAs(ctx, userId, audit.ActionEmailVerify, "user", userId,
+		gin.H{"status": lifecycle.StatusPending}, gin.H{"status": lifecycle.StatusActive, "email_verified": true})
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, "Email verified, you can log in now"),
+	})
+}
+
+// ResendEmailVerificationHandler sends a new verification link
+// @Summary Resend verification email
+// @Description Send a new verification link if the email belongs to an account waiting for verification. The response is the same either way.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param email body models.ResendVerificationRequest true "Registered email"
+// @Success 202 {object} models.Success
+// @Failure 400 {object} models.Errors
+// @Failure 429 {object} models.Errors
+// @Router /auth/email/verify/resend [post]
+func (h *Handler) ResendEmailVerificationHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ResendEmailVerificationHandler request")
+
+	req := models.ResendVerificationRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	// Hisob bor-yo'qligini oshkor qilmaslik uchun javob har doim bir xil
+	userId, email, err := h.UserRepo.PendingVerification(identifier.NormalizeEmail(req.Email))
+	if err == nil {
+		err = h.sendEmailVerification(ctx, userId, email)
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		h.Logger.Error("Error resending verification email", "error", err.Error())
+	}
+
+	ctx.JSON(http.StatusAccepted, &models.Success{
+		Message: h.t(ctx, "If the account is waiting for verification, a new link has been sent"),
+	})
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"auth-service/auth/token"
+	pb "auth-service/generated/auth_service"
+	"auth-service/jobs"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// RequestDataExportHandler starts an export of the user's personal data
+// @Summary Export my data
+// @Description Queue a ZIP archive of the account, profile, sessions, login history, consents and external identities. An email with a download link is sent when it is ready.
+// @Tags Privacy
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 202 {object} auth_service.DataExport
+// @Failure 401 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/export [post]
+func (h *Handler) RequestDataExportHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling RequestDataExportHandler request")
+
+	resp, err := h.ExportRepo.CreateExport(ctx.GetString("user_id"))
+	if err != nil {
+		h.Logger.Error("Error creating data export", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, resp)
+}
+
+// ListDataExportsHandler lists the user's data exports
+// @Summary My data exports
+// @Description Recent exports with a signed download link for ready ones
+// @Tags Privacy
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.ListDataExportsResponse
+// @Failure 401 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/exports [get]
+func (h *Handler) ListDataExportsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ListDataExportsHandler request")
+
+	resp, err := h.ExportRepo.ListExports(ctx.GetString("user_id"))
+	if err != nil {
+		h.Logger.Error("Error listing data exports", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	for _, export := range resp.Exports {
+		h.withDownloadURL(export)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetDataExportHandler returns one data export
+// @Summary Get data export
+// @Description Status of an export with a signed download link when ready
+// @Tags Privacy
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param id path string true "Export ID"
+// @Success 200 {object} auth_service.DataExport
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/exports/{id} [get]
+func (h *Handler) GetDataExportHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling GetDataExportHandler request")
+
+	resp, err := h.ExportRepo.GetExport(ctx.GetString("user_id"), ctx.Param("id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Export not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting data export", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	h.withDownloadURL(resp)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DownloadDataExportHandler serves an export archive through a signed link
+// @Summary Download data export
+// @Description Download the ZIP archive. The link from the email or the export status is signed and expires.
+// @Tags Privacy
+// @Produce application/zip
+// @Param id path string true "Export ID"
+// @Param expires query int true "Link expiry (unix seconds)"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Router /auth/exports/{id}/download [get]
+func (h *Handler) DownloadDataExportHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling DownloadDataExportHandler request")
+
+	id := ctx.Param("id")
+	expires := cast.ToInt64(ctx.Query("expires"))
+	if expires < time.Now().Unix() || !token.VerifyLink(h.Config.LINK_SIGNING_KEY, id, expires, ctx.Query("signature")) {
+		ctx.JSON(http.StatusForbidden, gin.H{
+			"Error": h.t(ctx, "Download link is invalid or expired"),
+		})
+		return
+	}
+
+	path, err := h.ExportRepo.ReadyFile(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Export not found or expired"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting data export file", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.FileAttachment(path, "dinereserve-data-"+id+".zip")
+}
+
+func (h *Handler) withDownloadURL(export *pb.DataExport) {
+	if export.Status != postgres.ExportStatusReady {
+		return
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, export.ExpiresAt)
+	if err != nil {
+		return
+	}
+	export.DownloadUrl = jobs.DownloadURL(h.Config, export.Id, expiresAt)
+}
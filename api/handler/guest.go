@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"auth-service/audit"
+	pb "auth-service/generated/auth_service"
+	"auth-service/models"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// GetSharingSettingsHandler returns what the user shares with restaurants
+// @Summary Get my sharing settings
+// @Description Which profile fields restaurants see when the user books. Nothing is shared until enabled.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.SharingSettings
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/sharing [get]
+func (h *Handler) GetSharingSettingsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling GetSharingSettingsHandler request")
+
+	resp, err := h.UserRepo.GetSharingSettings(ctx.GetString("user_id"))
+	if err != nil {
+		h.Logger.Error("Error getting sharing settings", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdateSharingSettingsHandler changes what the user shares with restaurants
+// @Summary Update my sharing settings
+// @Description Choose whether restaurants see the name, phone, allergies and dietary restrictions, and visit history
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param sharing body auth_service.SharingSettings true "Sharing settings"
+// @Success 200 {object} auth_service.SharingSettings
+// @Failure 400 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/sharing [put]
+func (h *Handler) UpdateSharingSettingsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling UpdateSharingSettingsHandler request")
+
+	settings := pb.SharingSettings{}
+	if err := ctx.ShouldBindJSON(&settings); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	settings.UserId = ctx.GetString("user_id")
+
+	before, err := h.UserRepo.GetSharingSettings(settings.UserId)
+	if err != nil {
+		h.Logger.Error("Error getting sharing settings", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update sharing settings")})
+		return
+	}
+
+	if err := h.UserRepo.SetSharingSettings(&settings); err != nil {
+		h.Logger.Error("Error updating sharing settings", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update sharing settings")})
+		return
+	}
+
+	resp, err := h.UserRepo.GetSharingSettings(settings.UserId)
+	if err != nil {
+		h.Logger.Error("Error getting sharing settings", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update sharing settings")})
+		return
+	}
+
+	h.audit(ctx, audit.ActionProfileUpdate, "user", settings.UserId, before, resp)
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// ListGuestAccessHandler shows which restaurants viewed the user's guest profile
+// @Summary My guest profile access log
+// @Description Every time a restaurant reads the guest profile, newest first
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} models.GuestAccessLog
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/sharing/access-log [get]
+func (h *Handler) ListGuestAccessHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ListGuestAccessHandler request")
+
+	resp, err := h.AuditRepo.ListAuditLogs(&pb.ListAuditLogsRequest{
+		Action:     audit.ActionGuestProfileAccess,
+		TargetType: "user",
+		TargetId:   ctx.GetString("user_id"),
+		Limit:      cast.ToInt32(ctx.Query("limit")),
+		Offset:     cast.ToInt32(ctx.Query("offset")),
+	})
+	if err != nil {
+		h.Logger.Error("Error listing guest profile access", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, h.guestAccessLog(ctx, resp))
+}
+
+// guestAccessLog audit yozuvlarini dinerga ko'rsatiladigan ko'rinishga
+// keltiradi. Restoran nomi topilmasa bo'sh qoladi.
+func (h *Handler) guestAccessLog(ctx *gin.Context, resp *pb.ListAuditLogsResponse) *models.GuestAccessLog {
+	c, cancel := context.WithTimeout(ctx.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	accessLog := &models.GuestAccessLog{Accesses: []*models.GuestAccess{}, Total: resp.Total}
+	names := map[string]string{}
+	for _, log := range resp.Logs {
+		var after struct {
+			RestaurantId string   `json:"restaurant_id"`
+			Fields       []string `json:"fields"`
+		}
+		if err := json.Unmarshal([]byte(log.After), &after); err != nil {
+			h.Logger.Error("Error reading guest access entry", "id", log.Id, "error", err.Error())
+		}
+
+		name, ok := names[after.RestaurantId]
+		if !ok && after.RestaurantId != "" {
+			var err error
+			if name, err = h.Restaurants.Name(c, after.RestaurantId); err != nil {
+				h.Logger.Warn("Error getting restaurant name", "restaurant_id", after.RestaurantId, "error", err.Error())
+			}
+			names[after.RestaurantId] = name
+		}
+
+		accessLog.Accesses = append(accessLog.Accesses, &models.GuestAccess{
+			RestaurantId:   after.RestaurantId,
+			RestaurantName: name,
+			Action:         log.Action,
+			Fields:         after.Fields,
+			AccessedAt:     log.CreatedAt,
+		})
+	}
+	return accessLog
+}
+
+// GetGuestProfileHandler returns the fields a diner shares with restaurants
+// @Summary Get guest profile
+// @Description For restaurant members or the restaurant's API keys with guests:read. Only fields the diner agreed to share are filled; every access is logged.
+// @Tags Restaurants
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param restaurant-id path string true "Restaurant ID"
+// @Param user-id path string true "User ID"
+// @Success 200 {object} auth_service.GuestProfile
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /restaurants/{restaurant-id}/guests/{user-id} [get]
+func (h *Handler) GetGuestProfileHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling GetGuestProfileHandler request")
+
+	restaurantId, userId := ctx.Param("restaurant-id"), ctx.Param("user-id")
+	guest, err := h.UserRepo.GetGuestProfile(restaurantId, userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "User not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting guest profile", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	actorId := auditActor(ctx)
+	if keyId := ctx.GetString("api_key_id"); keyId != "" {
+		actorId = audit.APIKeyActor(keyId)
+	}
+	h.auditAs(ctx, actorId, audit.ActionGuestProfileAccess, "user", userId, nil,
+		gin.H{"restaurant_id": restaurantId, "fields": guest.SharedFields})
+
+	ctx.JSON(http.StatusOK, guest)
+}
@@ -1,19 +1,60 @@
 package handler
 
 import (
+	"auth-service/config"
+	"auth-service/i18n"
+	"auth-service/notify"
+	"auth-service/reservation"
+	"auth-service/storage/blob"
 	"auth-service/storage/postgres"
 	"database/sql"
 	"log/slog"
+
+	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
-	UserRepo *postgres.UserRepo
-	Logger *slog.Logger
+	UserRepo        *postgres.UserRepo
+	SessionRepo     *postgres.SessionRepo
+	LoginEventRepo  *postgres.LoginEventRepo
+	AuditRepo       *postgres.AuditRepo
+	DeviceRepo      *postgres.DeviceRepo
+	LoginAlertRepo  *postgres.LoginAlertRepo
+	RestaurantRepo  *postgres.RestaurantRepo
+	APIKeyRepo      *postgres.APIKeyRepo
+	ExportRepo      *postgres.ExportRepo
+	EmailChangeRepo *postgres.EmailChangeRepo
+	ConsentRepo     *postgres.ConsentRepo
+	Blobs           blob.Store
+	Notifier        notify.Notifier
+	Restaurants     reservation.Restaurants
+	Config          config.Config
+	Logger          *slog.Logger
 }
 
 func NewHandler(db *sql.DB, logger *slog.Logger) *Handler {
+	cfg := config.Load()
 	return &Handler{
-		UserRepo: postgres.NewUserRepo(db),
-		Logger: logger,
+		UserRepo:        postgres.NewUserRepo(db),
+		SessionRepo:     postgres.NewSessionRepo(db),
+		LoginEventRepo:  postgres.NewLoginEventRepo(db),
+		AuditRepo:       postgres.NewAuditRepo(db),
+		DeviceRepo:      postgres.NewDeviceRepo(db),
+		LoginAlertRepo:  postgres.NewLoginAlertRepo(db),
+		RestaurantRepo:  postgres.NewRestaurantRepo(db),
+		APIKeyRepo:      postgres.NewAPIKeyRepo(db),
+		ExportRepo:      postgres.NewExportRepo(db),
+		EmailChangeRepo: postgres.NewEmailChangeRepo(db),
+		ConsentRepo:     postgres.NewConsentRepo(db),
+		Blobs:           blob.New(cfg),
+		Notifier:        notify.New(cfg),
+		Restaurants:     reservation.New(cfg),
+		Config:          cfg,
+		Logger:          logger,
 	}
-}
\ No newline at end of file
+}
+
+// t xabarni so'rov tiliga tarjima qiladi (LocaleMiddleware va token claim)
+func (h *Handler) t(ctx *gin.Context, msg string, args ...interface{}) string {
+	return i18n.T(ctx.GetString("locale"), msg, args...)
+}
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/token"
+	pb "auth-service/generated/auth_service"
+	"auth-service/i18n"
+	"auth-service/models"
+	"auth-service/notify"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImpersonateHandler issues a short-lived token to act as another user
+// @Summary Impersonate user
+// @Description Issue a 15 minute, non-refreshable token for support to reproduce a user's problem
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Success 200 {object} auth_service.ImpersonateResponse
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/impersonate [post]
+func (h *Handler) ImpersonateHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ImpersonateHandler request")
+
+	actorId := ctx.GetString("user_id")
+	user, err := h.UserRepo.GetUserById(ctx.Param("user-id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "User not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting user", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to start impersonation")})
+		return
+	}
+
+	if user.UserId == actorId || user.Role == models.RoleAdmin {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Admins cannot be impersonated"),
+		})
+		return
+	}
+
+	user.SessionId, err = h.SessionRepo.CreateImpersonationSession(user.UserId, actorId, ctx.Request.UserAgent(), ctx.ClientIP(), token.ImpersonationTTL)
+	if err != nil {
+		h.Logger.Error("Error creating impersonation session", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to start impersonation")})
+		return
+	}
+
+	accessToken, err := token.GenerateImpersonationJWT(user, actorId)
+	if err != nil {
+		h.Logger.Error("Error generating impersonation token", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to start impersonation")})
+		return
+	}
+
+	h.audit(ctx, audit.ActionImpersonationStart, "user", user.UserId, nil, gin.H{"session_id": user.SessionId})
+
+	h.notify(notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      user.Email,
+		Subject: i18n.T(user.Locale, "DineReserve support accessed your account"),
+		Body: i18n.T(user.Locale, "A DineReserve support agent signed in to your account at your request to investigate a problem. "+
+			"The access ends automatically within %d minutes and appears in your list of active sessions.", int(token.ImpersonationTTL.Minutes())),
+	})
+
+	ctx.JSON(http.StatusOK, &pb.ImpersonateResponse{
+		AccessToken: accessToken,
+		SessionId:   user.SessionId,
+		ExpiresIn:   int64(token.ImpersonationTTL.Seconds()),
+	})
+}
+
+// StopImpersonationHandler ends the current impersonation session
+// @Summary Stop impersonation
+// @Description End the support session used by the current impersonation token
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} models.Success
+// @Failure 400 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/impersonation/stop [post]
+func (h *Handler) StopImpersonationHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling StopImpersonationHandler request")
+
+	actorId := ctx.GetString("actor_id")
+	if actorId == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Not an impersonation session"),
+		})
+		return
+	}
+
+	userId, sessionId := ctx.GetString("user_id"), ctx.GetString("session_id")
+	if err := h.SessionRepo.RevokeSession(userId, sessionId); err != nil {
+		h.Logger.Error("Error revoking impersonation session", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to stop impersonation")})
+		return
+	}
+
+	h.auditAs(ctx, actorId, audit.ActionImpersonationStop, "user", userId, nil, gin.H{"session_id": sessionId})
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, "Impersonation stopped"),
+	})
+}
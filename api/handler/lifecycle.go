@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/lifecycle"
+	"auth-service/models"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// accountBlocked hisob faol bo'lmasa 403 va holat kodini yozadi
+func (h *Handler) accountBlocked(ctx *gin.Context, status string) bool {
+	if status == lifecycle.StatusActive {
+		return false
+	}
+
+	ctx.JSON(http.StatusForbidden, gin.H{
+		"error": h.t(ctx, lifecycle.Message(status)),
+		"code":  lifecycle.Code(status),
+	})
+	return true
+}
+
+// changeStatus holatni o'zgartiradi, faol bo'lmagan holatda sessiyalarni
+// bekor qiladi va audit yozadi. Javobni o'zi yozadi.
+func (h *Handler) changeStatus(ctx *gin.Context, userId, to, reason, until, message string) {
+	before, err := h.UserRepo.SetStatus(userId, to, reason, until)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "User not found"),
+		})
+		return
+	}
+	if errors.Is(err, postgres.ErrInvalidTransition) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, "Account cannot move from %s to %s", before.Status, to),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error changing account status", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	if to != lifecycle.StatusActive {
+		if _, err := h.SessionRepo.RevokeOtherSessions(userId, ""); err != nil {
+			h.Logger.Error("Error revoking sessions", "error", err.Error())
+		}
+	}
+
+	h.audit(ctx, audit.ActionStatusChange, "user", userId,
+		gin.H{"status": before.Status, "reason": before.Reason},
+		gin.H{"status": to, "reason": reason, "until": until})
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, message),
+	})
+}
+
+// SuspendUserHandler suspends a user account
+// @Summary Suspend user
+// @Description Suspend an account, optionally until a given time. All sessions are signed out.
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Param suspend body models.SuspendUserRequest true "Reason and optional expiry"
+// @Success 200 {object} models.Success
+// @Failure 400 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/suspend [post]
+func (h *Handler) SuspendUserHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling SuspendUserHandler request")
+
+	req := models.SuspendUserRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Reason is required"),
+		})
+		return
+	}
+	if req.Until != "" {
+		until, err := time.Parse(time.RFC3339, req.Until)
+		if err != nil || until.Before(time.Now()) {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"Error": h.t(ctx, "until must be a future RFC3339 timestamp"),
+			})
+			return
+		}
+	}
+
+	userId := ctx.Param("user-id")
+	if userId == ctx.GetString("user_id") {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "You cannot suspend your own account"),
+		})
+		return
+	}
+
+	h.changeStatus(ctx, userId, lifecycle.StatusSuspended, req.Reason, req.Until, "User suspended successfully")
+}
+
+// ReinstateUserHandler makes a suspended, deactivated or pending account active
+// @Summary Reinstate user
+// @Description Make a suspended, deactivated or pending account active again
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Param reinstate body models.StatusChangeRequest false "Reason"
+// @Success 200 {object} models.Success
+// @Failure 404 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/reinstate [post]
+func (h *Handler) ReinstateUserHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ReinstateUserHandler request")
+
+	req := models.StatusChangeRequest{}
+	_ = ctx.ShouldBindJSON(&req)
+
+	h.changeStatus(ctx, ctx.Param("user-id"), lifecycle.StatusActive, strings.TrimSpace(req.Reason), "", "User reinstated successfully")
+}
+
+// GetAccountStatusHandler returns the lifecycle status of a user
+// @Summary Get account status
+// @Description Current status with reason, suspension expiry and change time
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Success 200 {object} auth_service.AccountStatus
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/status [get]
+func (h *Handler) GetAccountStatusHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling GetAccountStatusHandler request")
+
+	resp, err := h.UserRepo.GetAccountStatus(ctx.Param("user-id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "User not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting account status", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeactivateAccountHandler lets a user deactivate their own account
+// @Summary Deactivate my account
+// @Description Deactivate the account and sign out everywhere. Support can reactivate it.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param deactivate body models.StatusChangeRequest false "Reason"
+// @Success 200 {object} models.Success
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/deactivate [post]
+func (h *Handler) DeactivateAccountHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling DeactivateAccountHandler request")
+
+	req := models.StatusChangeRequest{}
+	_ = ctx.ShouldBindJSON(&req)
+
+	h.changeStatus(ctx, ctx.GetString("user_id"), lifecycle.StatusDeactivated, strings.TrimSpace(req.Reason), "", "Account deactivated successfully")
+}
+
+// AdminDeleteUserHandler schedules deletion of a user account
+// @Summary Delete User
+// @Description Schedule deletion after the grace period and sign the user out everywhere. The account can be restored until then; the purge job anonymizes it afterwards.
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Param delete body models.StatusChangeRequest false "Reason"
+// @Success 200 {object} models.Success
+// @Failure 404 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id} [delete]
+func (h *Handler) AdminDeleteUserHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminDeleteUserHandler request")
+
+	req := models.StatusChangeRequest{}
+	_ = ctx.ShouldBindJSON(&req)
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		reason = "deleted by admin"
+	}
+
+	deleteAt := time.Now().UTC().Add(time.Duration(h.Config.DELETION_GRACE_DAYS) * 24 * time.Hour).Format(time.RFC3339)
+	h.changeStatus(ctx, ctx.Param("user-id"), lifecycle.StatusPendingDeletion, reason, deleteAt, "Account scheduled for deletion")
+}
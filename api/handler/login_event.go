@@ -0,0 +1,108 @@
+package handler
+
+import (
+	pb "auth-service/generated/auth_service"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// recordLogin kirish urinishini yozadi. reason bo'sh bo'lsa urinish muvaffaqiyatli.
+func (h *Handler) recordLogin(ctx *gin.Context, method, userId, identifier, reason string) {
+	err := h.LoginEventRepo.CreateLoginEvent(&pb.LoginEvent{
+		UserId:        userId,
+		Identifier:    identifier,
+		Success:       reason == "",
+		Ip:            ctx.ClientIP(),
+		UserAgent:     ctx.Request.UserAgent(),
+		Method:        method,
+		FailureReason: reason,
+	})
+	if err != nil {
+		h.Logger.Error("Error recording login event", "error", err.Error())
+	}
+}
+
+// SecurityEventsHandler returns the login history of the current user
+// @Summary My security events
+// @Description Successful and failed logins of the authenticated user, newest first
+// @Tags Security
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} auth_service.ListLoginEventsResponse
+// @Failure 401 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/security-events [get]
+func (h *Handler) SecurityEventsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling SecurityEventsHandler request")
+
+	resp, err := h.LoginEventRepo.ListLoginEvents(&pb.ListLoginEventsRequest{
+		UserId: ctx.GetString("user_id"),
+		Limit:  cast.ToInt32(ctx.Query("limit")),
+		Offset: cast.ToInt32(ctx.Query("offset")),
+	})
+	if err != nil {
+		h.Logger.Error("Error listing security events", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// AdminLoginEventsHandler searches login events of all users
+// @Summary Search login events
+// @Description Filter login events by user, IP and time range
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user_id query string false "User ID"
+// @Param ip query string false "IP address"
+// @Param from query string false "From (RFC3339)"
+// @Param to query string false "To (RFC3339)"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} auth_service.ListLoginEventsResponse
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/login-events [get]
+func (h *Handler) AdminLoginEventsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminLoginEventsHandler request")
+
+	filter := &pb.ListLoginEventsRequest{
+		UserId: ctx.Query("user_id"),
+		Ip:     ctx.Query("ip"),
+		From:   ctx.Query("from"),
+		To:     ctx.Query("to"),
+		Limit:  cast.ToInt32(ctx.Query("limit")),
+		Offset: cast.ToInt32(ctx.Query("offset")),
+	}
+	for _, t := range []string{filter.From, filter.To} {
+		if _, err := time.Parse(time.RFC3339, t); t != "" && err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{
+				"Error": h.t(ctx, "from and to must be RFC3339 timestamps"),
+			})
+			return
+		}
+	}
+
+	resp, err := h.LoginEventRepo.ListLoginEvents(filter)
+	if err != nil {
+		h.Logger.Error("Error listing login events", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
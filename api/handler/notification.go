@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"auth-service/audit"
+	pb "auth-service/generated/auth_service"
+	"auth-service/notify"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetNotificationPreferencesHandler returns the current user's notification preferences
+// @Summary Get my notification preferences
+// @Description Every category (security, reservation_reminders, marketing) and channel (email, sms, telegram, push) with its current value. Security notifications are locked on.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.NotificationPreferences
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/preferences/notifications [get]
+func (h *Handler) GetNotificationPreferencesHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling GetNotificationPreferencesHandler request")
+
+	h.notificationPreferences(ctx, ctx.GetString("user_id"))
+}
+
+// AdminGetNotificationPreferencesHandler returns a user's notification preferences
+// @Summary Get notification preferences
+// @Description Notification preferences of any user
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Success 200 {object} auth_service.NotificationPreferences
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/preferences/notifications [get]
+func (h *Handler) AdminGetNotificationPreferencesHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminGetNotificationPreferencesHandler request")
+
+	h.notificationPreferences(ctx, ctx.Param("user-id"))
+}
+
+func (h *Handler) notificationPreferences(ctx *gin.Context, userId string) {
+	resp, err := h.UserRepo.GetNotificationPreferences(userId)
+	if err != nil {
+		h.Logger.Error("Error getting notification preferences", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, "Failed to get notification preferences"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdateNotificationPreferencesHandler changes the current user's notification preferences
+// @Summary Update my notification preferences
+// @Description Set the listed category and channel pairs; pairs not listed keep their value. Turning off security notifications is rejected.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param preferences body auth_service.NotificationPreferences true "Notification preferences"
+// @Success 200 {object} auth_service.NotificationPreferences
+// @Failure 400 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/preferences/notifications [put]
+func (h *Handler) UpdateNotificationPreferencesHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling UpdateNotificationPreferencesHandler request")
+
+	req := pb.NotificationPreferences{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	userId := ctx.GetString("user_id")
+
+	prefs, err := notify.Normalize(req.Preferences)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	h.changeNotificationPreferences(ctx, userId, func() error {
+		return h.UserRepo.SetNotificationPreferences(userId, prefs)
+	})
+}
+
+// ResetNotificationPreferencesHandler restores the default notification preferences
+// @Summary Reset my notification preferences
+// @Description Forget all saved choices and go back to the defaults: security on everywhere, reservation reminders by email and push, no marketing.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.NotificationPreferences
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/preferences/notifications [delete]
+func (h *Handler) ResetNotificationPreferencesHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ResetNotificationPreferencesHandler request")
+
+	userId := ctx.GetString("user_id")
+	h.changeNotificationPreferences(ctx, userId, func() error {
+		return h.UserRepo.ResetNotificationPreferences(userId)
+	})
+}
+
+// changeNotificationPreferences write ni bajaradi, auditga yozadi va yangi
+// sozlamalarni qaytaradi
+func (h *Handler) changeNotificationPreferences(ctx *gin.Context, userId string, write func() error) {
+	before, err := h.UserRepo.GetNotificationPreferences(userId)
+	if err != nil {
+		h.Logger.Error("Error getting notification preferences", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update notification preferences")})
+		return
+	}
+
+	if err := write(); err != nil {
+		h.Logger.Error("Error updating notification preferences", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update notification preferences")})
+		return
+	}
+
+	resp, err := h.UserRepo.GetNotificationPreferences(userId)
+	if err != nil {
+		h.Logger.Error("Error getting notification preferences", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update notification preferences")})
+		return
+	}
+
+	h.audit(ctx, audit.ActionProfileUpdate, "user", userId, before, resp)
+
+	ctx.JSON(http.StatusOK, resp)
+}
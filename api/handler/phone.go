@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/token"
+	"auth-service/i18n"
+	"auth-service/models"
+	"auth-service/notify"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const phoneCodeTTL = 10 * time.Minute
+
+// SendPhoneCodeHandler sends a verification code to the profile phone number
+// @Summary Verify my phone number
+// @Description Send a 6 digit code by SMS to the phone number in the profile. Only verified numbers can be used to log in.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 202 {object} models.PhoneVerification
+// @Failure 400 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/phone/verify [post]
+func (h *Handler) SendPhoneCodeHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling SendPhoneCodeHandler request")
+
+	userId := ctx.GetString("user_id")
+	profile, err := h.UserRepo.GetUserProfileById(userId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		h.Logger.Error("Error getting user profile", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to send verification code")})
+		return
+	}
+	if profile.PhoneNumber == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Add a phone number to your profile first"),
+		})
+		return
+	}
+
+	taken, err := h.UserRepo.PhoneVerifiedElsewhere(userId, profile.PhoneNumber)
+	if err != nil {
+		h.Logger.Error("Error checking phone number", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to send verification code")})
+		return
+	}
+	if taken {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, "This phone number is verified by another account"),
+		})
+		return
+	}
+
+	code, err := token.GenerateCode()
+	if err != nil {
+		h.Logger.Error("Error generating phone code", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to send verification code")})
+		return
+	}
+
+	id, err := h.UserRepo.CreatePhoneVerification(userId, profile.PhoneNumber, token.HashOpaque(code), phoneCodeTTL)
+	if err != nil {
+		h.Logger.Error("Error creating phone verification", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to send verification code")})
+		return
+	}
+
+	locale := i18n.Resolve(profile.Locale, ctx.GetString("locale"))
+	h.notify(notify.Message{
+		Channel: notify.ChannelSMS,
+		To:      profile.PhoneNumber,
+		Body:    i18n.T(locale, "DineReserve: your phone verification code is %s. It expires in 10 minutes.", code),
+	})
+
+	ctx.JSON(http.StatusAccepted, &models.PhoneVerification{
+		ChallengeId: id,
+		Message:     h.t(ctx, "Verification code sent"),
+	})
+}
+
+// ConfirmPhoneHandler marks the profile phone number as verified
+// @Summary Confirm my phone number
+// @Description Check the SMS code. After this the phone number can be used to log in.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param Confirm body models.ConfirmPhoneRequest true "Challenge and code"
+// @Success 200 {object} models.Success
+// @Failure 400 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/phone/confirm [post]
+func (h *Handler) ConfirmPhoneHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ConfirmPhoneHandler request")
+
+	req := models.ConfirmPhoneRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	userId := ctx.GetString("user_id")
+	phoneNumber, err := h.UserRepo.VerifyPhone(userId, req.ChallengeId, token.HashOpaque(req.Code))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Invalid or expired code"),
+		})
+		return
+	}
+	if errors.Is(err, postgres.ErrPhoneTaken) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, "This phone number is verified by another account"),
+		})
+		return
+	}
+	if errors.Is(err, postgres.ErrPhoneChanged) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, "Phone number has changed, request a new code"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error verifying phone number", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to verify code")})
+		return
+	}
+
+	h.audit(ctx, audit.ActionPhoneVerify, "user", userId, nil, gin.H{"phone_number": phoneNumber})
+
+	ctx.JSON(http.StatusOK, &models.Success{
+		Message: h.t(ctx, "Phone number verified"),
+	})
+}
@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"auth-service/audit"
+	pb "auth-service/generated/auth_service"
+	"auth-service/profile"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// profileETag profil versiyasidan ETag
+func profileETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion If-Match sarlavhasidan versiyani o'qiydi. "*" istalgan
+// versiyani bildiradi (0). Sarlavha yo'q yoki noto'g'ri bo'lsa false.
+func ifMatchVersion(ctx *gin.Context) (int64, bool) {
+	value := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if value == "*" {
+		return 0, true
+	}
+	value = strings.TrimPrefix(value, "W/")
+	version, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// profileChange profil tarixi uchun o'zgartiruvchini aniqlaydi.
+// Impersonatsiyada support xodimi yoziladi.
+func profileChange(ctx *gin.Context, channel string) profile.Change {
+	return profile.Change{ActorId: auditActor(ctx), Channel: channel}
+}
+
+// updateProfile tekshirilgan maydonlarni version mos kelsa yozadi va audit
+// qiladi. Xato bo'lsa javobni o'zi yozib false qaytaradi.
+func (h *Handler) updateProfile(ctx *gin.Context, userId string, fields map[string]string, version int64, change profile.Change) (*pb.GetUserProfileResponse, bool) {
+	before, err := h.UserRepo.GetUserProfileById(userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Profile not found"),
+		})
+		return nil, false
+	}
+	if err != nil {
+		h.Logger.Error("Error getting profile", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update profile")})
+		return nil, false
+	}
+
+	current, err := h.UserRepo.UpdateProfileFields(userId, fields, version, change)
+	if errors.Is(err, postgres.ErrVersionConflict) {
+		ctx.Header("ETag", profileETag(current))
+		ctx.JSON(http.StatusPreconditionFailed, gin.H{
+			"Error": h.t(ctx, "Profile was changed by another request, reload it and try again"),
+		})
+		return nil, false
+	}
+	if err != nil {
+		h.Logger.Error("Error updating profile", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update profile")})
+		return nil, false
+	}
+
+	after, err := h.UserRepo.GetUserProfileById(userId)
+	if err != nil {
+		h.Logger.Error("Error getting profile", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to update profile")})
+		return nil, false
+	}
+
+	h.audit(ctx, audit.ActionProfileUpdate, "user", userId, before, after)
+	ctx.Header("ETag", profileETag(after.Version))
+	return after, true
+}
+
+// getProfile profilni ETag bilan qaytaradi
+func (h *Handler) getProfile(ctx *gin.Context, userId string) {
+	resp, err := h.UserRepo.GetUserProfileById(userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Profile not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting profile", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to get profile")})
+		return
+	}
+
+	ctx.Header("ETag", profileETag(resp.Version))
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetMyProfileHandler retrieves the current user's profile
+// @Summary Get my profile
+// @Description Get the profile of the authenticated user. The ETag header carries the profile version.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.GetUserProfileResponse
+// @Failure 401 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/profile [get]
+func (h *Handler) GetMyProfileHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling GetMyProfileHandler request")
+	h.getProfile(ctx, ctx.GetString("user_id"))
+}
+
+// AdminGetUserProfileHandler retrieves any user's profile
+// @Summary Get user profile
+// @Description Get the profile of any user. The ETag header carries the profile version.
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Success 200 {object} auth_service.GetUserProfileResponse
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/profile [get]
+func (h *Handler) AdminGetUserProfileHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminGetUserProfileHandler request")
+	h.getProfile(ctx, ctx.Param("user-id"))
+}
+
+// replaceProfile update_mask dagi (yoki barcha) maydonlarni yozadi
+func (h *Handler) replaceProfile(ctx *gin.Context, userId, channel string) {
+	req := pb.UpdateUserProfileRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	fields, err := profile.FromRequest(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	// Versiya If-Match yoki body dagi version dan olinadi
+	version, ok := ifMatchVersion(ctx)
+	if !ok && req.Version <= 0 {
+		ctx.JSON(http.StatusPreconditionRequired, gin.H{
+			"Error": h.t(ctx, "If-Match header or version is required"),
+		})
+		return
+	}
+	if !ok {
+		version = req.Version
+	}
+
+	after, ok := h.updateProfile(ctx, userId, fields, version, profileChange(ctx, channel))
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &pb.UpdateUserProfileResponse{
+		Message: h.t(ctx, "User updated successfully"),
+		Version: after.Version,
+	})
+}
+
+// UpdateMyProfileHandler updates the current user's profile
+// @Summary Update my profile
+// @Description Update the profile of the authenticated user. Only fields listed in update_mask change; an empty mask replaces all of them.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param If-Match header string false "Profile ETag, or send version in the body"
+// @Param profile body models.UpdateProfileRequest true "Profile"
+// @Success 200 {object} auth_service.UpdateUserProfileResponse
+// @Failure 400 {object} models.Errors
+// @Failure 401 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 412 {object} models.Errors
+// @Failure 428 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/profile [put]
+func (h *Handler) UpdateMyProfileHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling UpdateMyProfileHandler request")
+	h.replaceProfile(ctx, ctx.GetString("user_id"), profile.ChannelAPI)
+}
+
+// patchProfile merge patch bo'yicha maydonlarni yozadi
+func (h *Handler) patchProfile(ctx *gin.Context, userId, channel string) {
+	switch ctx.ContentType() {
+	case "application/merge-patch+json", "application/json":
+	default:
+		ctx.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"Error": h.t(ctx, "Content-Type must be application/merge-patch+json"),
+		})
+		return
+	}
+
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		ctx.JSON(http.StatusPreconditionRequired, gin.H{
+			"Error": h.t(ctx, "If-Match header with the profile ETag is required"),
+		})
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, 64<<10))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	fields, err := profile.FromMergePatch(body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	resp, ok := h.updateProfile(ctx, userId, fields, version, profileChange(ctx, channel))
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// PatchProfileHandler partially updates the current user's profile
+// @Summary Patch my profile
+// @Description JSON merge patch (RFC 7396) of fullname, date_of_birth (YYYY-MM-DD), phone_number and address. Omitted keys stay unchanged, null clears a field. Changing the phone number resets its verification. If-Match must carry the profile ETag.
+// @Tags Auth
+// @Accept json
+// @Accept application/merge-patch+json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param If-Match header string true "Profile ETag"
+// @Param profile body object true "Fields to change"
+// @Success 200 {object} auth_service.GetUserProfileResponse
+// @Failure 400 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 412 {object} models.Errors
+// @Failure 415 {object} models.Errors
+// @Failure 428 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/profile [patch]
+func (h *Handler) PatchProfileHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling PatchProfileHandler request")
+	h.patchProfile(ctx, ctx.GetString("user_id"), profile.ChannelAPI)
+}
+
+// AdminPatchProfileHandler partially updates any user's profile
+// @Summary Patch user profile
+// @Description JSON merge patch (RFC 7396) of another user's fullname, date_of_birth, phone_number and address. If-Match must carry the profile ETag.
+// @Tags Admin
+// @Accept json
+// @Accept application/merge-patch+json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Param If-Match header string true "Profile ETag"
+// @Param profile body object true "Fields to change"
+// @Success 200 {object} auth_service.GetUserProfileResponse
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 412 {object} models.Errors
+// @Failure 415 {object} models.Errors
+// @Failure 428 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/profile [patch]
+func (h *Handler) AdminPatchProfileHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminPatchProfileHandler request")
+	h.patchProfile(ctx, ctx.Param("user-id"), profile.ChannelAdmin)
+}
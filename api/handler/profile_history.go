@@ -0,0 +1,154 @@
+package handler
+
+import (
+	pb "auth-service/generated/auth_service"
+	"auth-service/models"
+	"auth-service/profile"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/cast"
+)
+
+// AdminProfileHistoryHandler lists versions of a user's profile
+// @Summary Profile history
+// @Description Every profile version with who changed it, through which channel and which fields changed. Newest first.
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Param limit query int false "Limit"
+// @Param offset query int false "Offset"
+// @Success 200 {object} auth_service.ListProfileHistoryResponse
+// @Failure 403 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/profile/history [get]
+func (h *Handler) AdminProfileHistoryHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminProfileHistoryHandler request")
+
+	resp, err := h.UserRepo.ListProfileHistory(&pb.ListProfileHistoryRequest{
+		UserId: ctx.Param("user-id"),
+		Limit:  cast.ToInt32(ctx.Query("limit")),
+		Offset: cast.ToInt32(ctx.Query("offset")),
+	})
+	if err != nil {
+		h.Logger.Error("Error listing profile history", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to list profile history")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// AdminProfileAsOfHandler shows a user's profile as it was at a point in time
+// @Summary Profile as of a time
+// @Description The profile version that was current at the given time, e.g. when a reservation was made
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Param at query string true "Point in time (RFC3339)"
+// @Success 200 {object} auth_service.ProfileVersion
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/profile/as-of [get]
+func (h *Handler) AdminProfileAsOfHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminProfileAsOfHandler request")
+
+	at := ctx.Query("at")
+	if _, err := time.Parse(time.RFC3339, at); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "at must be an RFC3339 timestamp"),
+		})
+		return
+	}
+
+	resp, err := h.UserRepo.GetProfileAsOf(ctx.Param("user-id"), at)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Profile did not exist at that time"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting profile version", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to get profile")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// AdminRevertProfileHandler restores a prior version of a user's profile
+// @Summary Revert profile
+// @Description Copy fullname, date of birth, phone number and address from a prior version. Username and avatar are not reverted. The result is recorded as a new version. Needs If-Match or current_version.
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Param If-Match header string false "Profile ETag, or send current_version in the body"
+// @Param revert body models.RevertProfileRequest true "Version to restore"
+// @Success 200 {object} auth_service.UpdateUserProfileResponse
+// @Failure 400 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 412 {object} models.Errors
+// @Failure 428 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/profile/revert [post]
+func (h *Handler) AdminRevertProfileHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminRevertProfileHandler request")
+
+	req := models.RevertProfileRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	version, ok := ifMatchVersion(ctx)
+	if !ok && req.CurrentVersion <= 0 {
+		ctx.JSON(http.StatusPreconditionRequired, gin.H{
+			"Error": h.t(ctx, "If-Match header or current_version is required"),
+		})
+		return
+	}
+	if !ok {
+		version = req.CurrentVersion
+	}
+
+	id := ctx.Param("user-id")
+	target, err := h.UserRepo.GetProfileVersion(id, req.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Profile version not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting profile version", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to revert profile")})
+		return
+	}
+
+	change := profileChange(ctx, profile.ChannelAdmin)
+	change.RevertedFrom = target.Version
+	after, ok := h.updateProfile(ctx, id, profile.RevertFields(target), version, change)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &pb.UpdateUserProfileResponse{
+		Message: h.t(ctx, "Profile reverted successfully"),
+		Version: after.Version,
+	})
+}
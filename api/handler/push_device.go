@@ -0,0 +1,175 @@
+package handler
+
+import (
+	pb "auth-service/generated/auth_service"
+	"auth-service/notify"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListPushDevicesHandler lists the current user's push devices
+// @Summary List my push devices
+// @Description FCM and APNs tokens registered by the user's active sessions
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.ListUserDevicesResponse
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/push-devices [get]
+func (h *Handler) ListPushDevicesHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ListPushDevicesHandler request")
+
+	devices, err := h.SessionRepo.ListPushDevices(ctx.GetString("user_id"), "")
+	if err != nil {
+		h.Logger.Error("Error listing push devices", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, "Failed to list push devices"),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &pb.ListUserDevicesResponse{
+		Devices: devices,
+	})
+}
+
+// RegisterPushDeviceHandler links a push token to the current session
+// @Summary Register push device
+// @Description Register an FCM or APNs token for the session of this access token. Registering a token again moves it to this session. The token is removed when the session is logged out or revoked.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param device body auth_service.RegisterPushDeviceRequest true "Provider (fcm or apns) and token"
+// @Success 201 {object} auth_service.PushDevice
+// @Failure 400 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/push-devices [post]
+func (h *Handler) RegisterPushDeviceHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling RegisterPushDeviceHandler request")
+
+	req := pb.RegisterPushDeviceRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	provider, token, err := notify.NormalizePushToken(req.Provider, req.Token)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	device, err := h.SessionRepo.RegisterPushDevice(ctx.GetString("user_id"), ctx.GetString("session_id"), provider, token)
+	if err != nil {
+		h.Logger.Error("Error registering push device", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to register push device")})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, device)
+}
+
+// RefreshPushDeviceHandler replaces the token of a push device
+// @Summary Refresh push device
+// @Description Store the new token the provider issued for a registered device and link it to the current session
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param id path string true "Push device ID"
+// @Param device body auth_service.RefreshPushDeviceRequest true "New token"
+// @Success 200 {object} auth_service.PushDevice
+// @Failure 400 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/push-devices/{id} [put]
+func (h *Handler) RefreshPushDeviceHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling RefreshPushDeviceHandler request")
+
+	req := pb.RefreshPushDeviceRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	userId := ctx.GetString("user_id")
+
+	device, err := h.SessionRepo.GetPushDevice(userId, ctx.Param("id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Push device not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error getting push device", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to refresh push device")})
+		return
+	}
+
+	_, token, err := notify.NormalizePushToken(device.Provider, req.Token)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	device, err = h.SessionRepo.RefreshPushDevice(userId, ctx.GetString("session_id"), device.Id, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Push device not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error refreshing push device", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to refresh push device")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, device)
+}
+
+// RemovePushDeviceHandler removes a push device
+// @Summary Remove push device
+// @Description Stop sending push notifications to a device
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param id path string true "Push device ID"
+// @Success 200 {object} auth_service.RemovePushDeviceResponse
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/push-devices/{id} [delete]
+func (h *Handler) RemovePushDeviceHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling RemovePushDeviceHandler request")
+
+	err := h.SessionRepo.RemovePushDevice(ctx.GetString("user_id"), ctx.Param("id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Push device not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error removing push device", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to remove push device")})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &pb.RemovePushDeviceResponse{
+		Message: h.t(ctx, "Push device removed successfully"),
+	})
+}
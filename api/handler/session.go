@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"auth-service/audit"
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListSessionsHandler lists active sessions of the current user
+// @Summary List sessions
+// @Description List devices where the authenticated user is logged in
+// @Tags Sessions
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.ListSessionsResponse
+// @Failure 401 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/sessions [get]
+func (h *Handler) ListSessionsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ListSessionsHandler request")
+
+	resp, err := h.SessionRepo.ListSessions(&pb.ListSessionsRequest{
+		UserId:           ctx.GetString("user_id"),
+		CurrentSessionId: ctx.GetString("session_id"),
+	})
+	if err != nil {
+		h.Logger.Error("Error listing sessions", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// RevokeSessionHandler revokes a single session of the current user
+// @Summary Revoke session
+// @Description Log out one device of the authenticated user
+// @Tags Sessions
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param id path string true "Session ID"
+// @Success 200 {object} auth_service.RevokeSessionResponse
+// @Failure 401 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/sessions/{id} [delete]
+func (h *Handler) RevokeSessionHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling RevokeSessionHandler request")
+
+	err := h.SessionRepo.RevokeSession(ctx.GetString("user_id"), ctx.Param("id"))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "Session not found"),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error revoking session", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &pb.RevokeSessionResponse{
+		Message: h.t(ctx, "Session revoked successfully"),
+	})
+}
+
+// RevokeOtherSessionsHandler revokes every session except the current one
+// @Summary Revoke other sessions
+// @Description Log out all other devices of the authenticated user
+// @Tags Sessions
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.RevokeOtherSessionsResponse
+// @Failure 401 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/sessions [delete]
+func (h *Handler) RevokeOtherSessionsHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling RevokeOtherSessionsHandler request")
+
+	revoked, err := h.SessionRepo.RevokeOtherSessions(ctx.GetString("user_id"), ctx.GetString("session_id"))
+	if err != nil {
+		h.Logger.Error("Error revoking sessions", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &pb.RevokeOtherSessionsResponse{
+		Message: h.t(ctx, "Other sessions revoked successfully"),
+		Revoked: revoked,
+	})
+}
+
+// LogoutHandler ends the current session
+// @Summary Log out
+// @Description Revoke the session of the access token used for this request. Its refresh token stops working too.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Success 200 {object} auth_service.LogoutResponse
+// @Failure 401 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/logout [post]
+func (h *Handler) LogoutHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling LogoutHandler request")
+
+	err := h.SessionRepo.RevokeSession(ctx.GetString("user_id"), ctx.GetString("session_id"))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		h.Logger.Error("Error revoking session", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &pb.LogoutResponse{
+		Message: h.t(ctx, "Logged out successfully"),
+	})
+}
+
+// AdminLogoutUserHandler ends every session of a user
+// @Summary Log out user
+// @Description Revoke all sessions of any user, signing them out on every device
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Success 200 {object} auth_service.RevokeOtherSessionsResponse
+// @Failure 403 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/logout [post]
+func (h *Handler) AdminLogoutUserHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminLogoutUserHandler request")
+
+	id := ctx.Param("user-id")
+	revoked, err := h.SessionRepo.RevokeOtherSessions(id, "")
+	if err != nil {
+		h.Logger.Error("Error revoking sessions", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	h.audit(ctx, audit.ActionForceLogout, "user", id, nil, gin.H{"revoked": revoked})
+
+	ctx.JSON(http.StatusOK, &pb.RevokeOtherSessionsResponse{
+		Message: h.t(ctx, "Sessions revoked successfully"),
+		Revoked: revoked,
+	})
+}
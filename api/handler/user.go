@@ -1,9 +1,18 @@
 package handler
 
 import (
+	"auth-service/auth/device"
+	"auth-service/auth/identifier"
+	"auth-service/auth/lifecycle"
 	"auth-service/auth/token"
+	"auth-service/auth/username"
 	pb "auth-service/generated/auth_service"
+	"auth-service/profile"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,13 +21,15 @@ import (
 
 // RegisterHandler handles user registration
 // @Summary Register a new user
-// @Description Register a new user with email and password
+// @Description Register a new user with email and password. accepted_documents must list the ids of all current legal documents (GET /auth/legal-documents). The account starts as pending_verification and a verification link is sent to the email.
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param Register body auth_service.RegisterRequest true "User Registration"
 // @Success 201 {object} models.Success
 // @Failure 400 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 429 {object} models.Errors
 // @Router /auth/register [post]
 func (h *Handler) RegisterHandler(ctx *gin.Context) {
 	h.Logger.Info("Handling RegisterHandler request")
@@ -28,16 +39,61 @@ func (h *Handler) RegisterHandler(ctx *gin.Context) {
 	if err := ctx.ShouldBindJSON(&user); err != nil {
 		h.Logger.Error("Error binding JSON:", "error", err.Error())
 		ctx.JSON(http.StatusBadRequest, gin.H{
-			"Error": err.Error(),
+			"Error": h.t(ctx, err.Error()),
 		})
 		return
 	}
 
+	user.Email = identifier.NormalizeEmail(user.Email)
+	user.Username = strings.TrimSpace(user.Username)
+
+	if identifier.Kind(user.Username) != identifier.KindUsername {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, "Username must not be an email or phone number"),
+		})
+		return
+	}
+	if err := username.Validate(user.Username); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	if !h.requireRegistrationConsent(ctx, user.AcceptedDocuments) {
+		return
+	}
+
+	for _, check := range []struct {
+		exists  func(string) (bool, error)
+		value   string
+		message string
+	}{
+		{h.UserRepo.EmailExists, user.Email, "Email is already registered"},
+		{h.UserRepo.UsernameExists, user.Username, "Username is already taken"},
+		{h.usernameReleased, user.Username, "Username is already taken"},
+	} {
+		exists, err := check.exists(check.value)
+		if err != nil {
+			h.Logger.Error("Error checking identifier", "error", err.Error())
+			ctx.JSON(http.StatusInternalServerError, gin.H{
+				"Error": h.t(ctx, err.Error()),
+			})
+			return
+		}
+		if exists {
+			ctx.JSON(http.StatusConflict, gin.H{
+				"Error": h.t(ctx, check.message),
+			})
+			return
+		}
+	}
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
 	if err != nil {
 		h.Logger.Error("Error generating hashed password", "error", err.Error())
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"Error": err.Error(),
+			"Error": h.t(ctx, err.Error()),
 		})
 		return
 	}
@@ -48,27 +104,41 @@ func (h *Handler) RegisterHandler(ctx *gin.Context) {
 	if err != nil {
 		h.Logger.Error("Error register user", "error", err.Error())
 		ctx.JSON(http.StatusBadRequest, gin.H{
-			"Error": err.Error(),
+			"Error": h.t(ctx, err.Error()),
 		})
 		return
 	}
 
+	// Yozilmasa birinchi loginda qayta so'raladi
+	err = h.ConsentRepo.AcceptLegalDocuments(resp.UserId, user.AcceptedDocuments, ctx.ClientIP(), ctx.Request.UserAgent())
+	if err != nil {
+		h.Logger.Error("Error recording consent", "error", err.Error())
+	}
+
+	// Yuborilmasa foydalanuvchi /auth/email/verify/resend orqali qayta so'raydi
+	if err := h.sendEmailVerification(ctx, resp.UserId, user.Email); err != nil {
+		h.Logger.Error("Error sending verification email", "error", err.Error())
+	}
+
 	h.Logger.Info(resp.Message)
 	ctx.JSON(http.StatusCreated, gin.H{
-		"Message": resp.Message,
+		"Message": h.t(ctx, "User created, check your email to activate the account"),
 	})
 }
 
 // LoginHandler handles user login
 // @Summary Login a user
-// @Description Login a user with email and password
+// @Description Login with a username, email or verified phone number and password
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param Login body auth_service.LoginRequest true "User Login"
+// @Param X-Device-ID header string false "Stable device identifier sent by mobile apps"
 // @Success 200 {object} models.Token
+// @Success 202 {object} models.StepUpChallenge "step_up_required, or consent_required (models.ConsentChallenge) when new legal documents must be accepted"
 // @Failure 400 {object} models.Errors
 // @Failure 404 {object} models.Errors
+// @Failure 429 {object} models.Errors
 // @Failure 500 {object} models.Errors
 // @Router /auth/login [post]
 func (h *Handler) LoginHandler(ctx *gin.Context) {
@@ -79,140 +149,155 @@ func (h *Handler) LoginHandler(ctx *gin.Context) {
 	if err := ctx.ShouldBindJSON(&user); err != nil {
 		h.Logger.Error("Error binding JSON:", "error", err.Error())
 		ctx.JSON(http.StatusBadRequest, gin.H{
-			"Error": err.Error(),
+			"Error": h.t(ctx, err.Error()),
 		})
 		return
 	}
 
-	storedUser, err := h.UserRepo.GetByEmail(user.Email)
+	storedUser, login := h.checkPassword(ctx, &user)
+	if storedUser == nil {
+		return
+	}
+
+	if h.accountBlocked(ctx, storedUser.Status) {
+		h.recordLogin(ctx, postgres.LoginMethodPassword, storedUser.UserId, login, lifecycle.Code(storedUser.Status))
+		return
+	}
+
+	h.loginFromDevice(ctx, storedUser, user.DeviceName, login)
+}
+
+// checkPassword login so'rovidagi identifikator va parolni tekshiradi.
+// Xato bo'lsa javobni yozib nil qaytaradi.
+func (h *Handler) checkPassword(ctx *gin.Context, user *pb.LoginRequest) (*pb.LoginResponse, string) {
+	// Eski klientlar email yoki username yuboradi
+	login := user.Identifier
+	if login == "" {
+		login = user.Email
+	}
+	if login == "" {
+		login = user.Username
+	}
+
+	storedUser, err := h.UserRepo.GetByIdentifier(login)
 	if err != nil {
-		h.Logger.Error("Error getting user by email", "error", err.Error())
+		h.Logger.Error("Error getting user by identifier", "error", err.Error())
+		reason := postgres.LoginFailureUserNotFound
+		if !errors.Is(err, sql.ErrNoRows) {
+			reason = postgres.LoginFailureInternal
+		}
+		h.recordLogin(ctx, postgres.LoginMethodPassword, "", login, reason)
 		ctx.JSON(http.StatusNotFound, gin.H{
-			"Error": err.Error(),
+			"Error": h.t(ctx, err.Error()),
 		})
-		return
+		return nil, login
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(storedUser.Password), []byte(user.Password)); err != nil {
 		h.Logger.Error("Invalid password", "error", err.Error())
+		h.recordLogin(ctx, postgres.LoginMethodPassword, storedUser.UserId, login, postgres.LoginFailureInvalidPassword)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-			"Error": err.Error(),
+			"Error": h.t(ctx, err.Error()),
 		})
+		return nil, login
+	}
+
+	return storedUser, login
+}
+
+// loginFromDevice yangi huquqiy hujjatlar qabul qilinishini va qurilmani
+// tekshiradi, kerak bo'lsa step-up so'raydi, aks holda loginni yakunlaydi
+func (h *Handler) loginFromDevice(ctx *gin.Context, storedUser *pb.LoginResponse, deviceName, login string) {
+	if h.requireLoginConsent(ctx, storedUser, deviceName) {
 		return
 	}
 
-	accessToken, err := token.GenerateAccessJWT(storedUser)
+	fingerprint := deviceFingerprint(ctx, deviceName)
+	check, err := h.DeviceRepo.CheckDevice(storedUser.UserId, fingerprint, device.IPRange(ctx.ClientIP()))
 	if err != nil {
-		h.Logger.Error("Error generating access token:", "error", err.Error())
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate access token"})
+		h.Logger.Error("Error checking device:", "error", err.Error())
+		h.recordLogin(ctx, postgres.LoginMethodPassword, storedUser.UserId, login, postgres.LoginFailureInternal)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to check device")})
 		return
 	}
 
-	refreshToken, err := token.GenerateRefreshJWT(storedUser)
-	if err != nil {
-		h.Logger.Error("Error generating refresh token:", "error", err.Error())
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate refresh token"})
+	if check.Suspicious() && h.Config.STEP_UP_NEW_DEVICE {
+		h.startStepUp(ctx, storedUser, deviceName)
 		return
 	}
 
-	h.Logger.Info("user login successfully")
-	ctx.JSON(http.StatusOK, gin.H{
-		"access_token":  accessToken,
-		"refresh_token": refreshToken,
-	})
+	h.completeLogin(ctx, storedUser, deviceName, postgres.LoginMethodPassword, check.Suspicious())
 }
 
-// LogoutUserHandler handles the logout of a user.
-// @Summary Logout User
-// @Description Logout the authenticated user
-// @Tags Auth
-// @Accept json
-// @Security ApiKeyAuth
-// @Produce json
-// @Param user-id path string true "User ID"
-// @Success 200 {object} auth_service.LogoutResponse
-// @Failure 400 {object} string "Bad Request"
-// @Router /api/auth/logout/{user-id} [post]
-func (h *Handler) LogoutUserHandler(ctx *gin.Context) {
-	h.Logger.Info("Handling LogoutUserHandler request")
-
-	id := ctx.Param("user-id")
-
-	resp, err := h.UserRepo.LogoutUser(id)
+// completeLogin sessiya ochadi, tokenlarni qaytaradi va qurilmani eslab
+// qoladi. suspicious bo'lsa foydalanuvchiga "bu men emasman" havolasi yuboriladi.
+func (h *Handler) completeLogin(ctx *gin.Context, storedUser *pb.LoginResponse, deviceName, method string, suspicious bool) {
+	if h.accountBlocked(ctx, storedUser.Status) {
+		h.recordLogin(ctx, method, storedUser.UserId, storedUser.Email, lifecycle.Code(storedUser.Status))
+		return
+	}
 
+	var err error
+	storedUser.SessionId, err = h.SessionRepo.CreateSession(storedUser.UserId, deviceName, ctx.Request.UserAgent(), ctx.ClientIP())
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"Error": err.Error(),
-		})
+		h.Logger.Error("Error creating session:", "error", err.Error())
+		h.recordLogin(ctx, method, storedUser.UserId, storedUser.Email, postgres.LoginFailureInternal)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to create session")})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, resp)
-}
-
-// GetUserProfileHandler retrieves the user profile.
-// @Summary Get User Profile
-// @Description Get profile of the authenticated user
-// @Tags Auth
-// @Accept json
-// @Security ApiKeyAuth
-// @Produce json
-// @Param username path string true "Username"
-// @Success 200 {object} auth_service.GetUserProfileResponse
-// @Failure 400 {object} string "Bad Request"
-// @Router /api/auth/profile/{username} [get]
-func (h *Handler) GetUserProfileHandler(ctx *gin.Context) {
-	h.Logger.Info("Handling GetUserProfileHandler request")
-
-	username := ctx.Param("username")
-
-	resp, err := h.UserRepo.GetUserProfile(username)
+	accessToken, err := token.GenerateAccessJWT(storedUser)
+	if err != nil {
+		h.Logger.Error("Error generating access token:", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to generate access token")})
+		return
+	}
 
+	refreshToken, err := token.GenerateRefreshJWT(storedUser)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"Error": err.Error(),
-		})
+		h.Logger.Error("Error generating refresh token:", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to generate refresh token")})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, resp)
+	fingerprint := deviceFingerprint(ctx, deviceName)
+	err = h.DeviceRepo.RememberDevice(storedUser.UserId, fingerprint, device.IPRange(ctx.ClientIP()), deviceName)
+	if err != nil {
+		h.Logger.Error("Error remembering device:", "error", err.Error())
+	}
+
+	if suspicious {
+		h.sendLoginAlert(ctx, storedUser, deviceName, fingerprint)
+	}
+
+	h.recordLogin(ctx, method, storedUser.UserId, storedUser.Email, "")
+	h.Logger.Info("user login successfully")
+	ctx.JSON(http.StatusOK, gin.H{
+		"access_token":  accessToken,
+		"refresh_token": refreshToken,
+	})
 }
 
 // UpdateUserProfile updates the user profile.
 // @Summary Update User Profile
-// @Description Update the profile of the authenticated user
-// @Tags Auth
+// @Description Update the profile of any user. Only fields listed in update_mask change; an empty mask replaces all of them.
+// @Tags Admin
 // @Accept json
 // @Security ApiKeyAuth
 // @Produce json
 // @Param user-id path string true "User ID"
-// @Param profile body auth_service.UpdateUserProfileRequest true "Profile"
+// @Param If-Match header string false "Profile ETag, or send version in the body"
+// @Param profile body models.UpdateProfileRequest true "Profile"
 // @Success 200 {object} auth_service.UpdateUserProfileResponse
-// @Failure 400 {object} string "Bad Request"
-// @Router /api/auth/profile/{user-id} [put]
+// @Failure 400 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 412 {object} models.Errors
+// @Failure 428 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/profile [put]
 func (h *Handler) UpdateUserProfile(ctx *gin.Context) {
 	h.Logger.Info("Handling UpdateUserProfile request")
-
-	id := ctx.Param("user-id")
-
-	profile := pb.UpdateUserProfileRequest{}
-	if err := ctx.ShouldBindJSON(&profile); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"Error": err.Error(),
-		})
-		return
-	}
-	profile.UserId = id
-
-	resp, err := h.UserRepo.UpdateUserProfile(&profile)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{
-			"Error": err.Error(),
-		})
-		return
-	}
-
-	ctx.JSON(http.StatusOK, resp)
+	h.replaceProfile(ctx, ctx.Param("user-id"), profile.ChannelAdmin)
 }
 
 // RefreshToken generates a new access token using a refresh token
@@ -225,6 +310,8 @@ func (h *Handler) UpdateUserProfile(ctx *gin.Context) {
 // @Success 200 {object} models.Request
 // @Failure 400 {object} models.Errors
 // @Failure 401 {object} models.Errors
+// @Failure 403 {object} models.Errors
+// @Failure 429 {object} models.Errors
 // @Failure 500 {object} models.Errors
 // @Security ApiKeyAuth
 // @Router /auth/refresh_token [get]
@@ -233,28 +320,58 @@ func (h *Handler) RefreshToken(c *gin.Context) {
 
 	refreshToken := c.GetHeader("Authorization")
 	if refreshToken == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Refresh token is required"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": h.t(c, "Refresh token is required")})
 		return
 	}
 
-	claims, err := token.ExtractClaim(refreshToken)
+	claims, err := token.ExtractRefreshClaim(refreshToken)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid token"})
+		if access, err := token.ExtractClaim(refreshToken); err == nil && access.Act != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": h.t(c, "Impersonation tokens cannot be refreshed")})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": h.t(c, "Invalid token")})
 		return
 	}
 
 	if claims.ExpiresAt < time.Now().Unix() {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has expired"})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": h.t(c, "Token has expired")})
+		return
+	}
+
+	status, err := h.SessionRepo.Touch(claims.SessionId)
+	if err != nil {
+		h.Logger.Error("Error checking session:", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": h.t(c, "Failed to check session")})
+		return
+	}
+	if status == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": h.t(c, "Session has been revoked")})
+		return
+	}
+	if h.accountBlocked(c, status) {
+		return
+	}
+
+	// Username, email, rol yoki til o'zgargan bo'lishi mumkin
+	user, err := h.UserRepo.GetUserById(claims.UserId)
+	if err != nil {
+		h.Logger.Error("Error getting user:", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": h.t(c, "Failed to generate new access token")})
 		return
 	}
 
 	newAccessToken, err := token.GenerateAccessJWT(&pb.LoginResponse{
-		UserId:   claims.UserId,
-		Username: claims.Username,
-		Email:    claims.Email,
+		UserId:    user.UserId,
+		Username:  user.Username,
+		Email:     user.Email,
+		SessionId: claims.SessionId,
+		Role:      user.Role,
+		Locale:    user.Locale,
+		Timezone:  user.Timezone,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate new access token"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": h.t(c, "Failed to generate new access token")})
 		return
 	}
 
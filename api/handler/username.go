@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/username"
+	pb "auth-service/generated/auth_service"
+	"auth-service/models"
+	"auth-service/profile"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// changeUsername nomni tekshiradi, o'zgartiradi va audit yozadi. Javobni o'zi yozadi.
+func (h *Handler) changeUsername(ctx *gin.Context, userId, channel string) {
+	req := models.ChangeUsernameRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	name := strings.TrimSpace(req.Username)
+	if err := username.Validate(name); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+
+	cooldown := time.Duration(h.Config.USERNAME_COOLDOWN_DAYS) * 24 * time.Hour
+	old, err := h.UserRepo.ChangeUsername(userId, name, cooldown, profileChange(ctx, channel))
+	if errors.Is(err, sql.ErrNoRows) {
+		ctx.JSON(http.StatusNotFound, gin.H{
+			"Error": h.t(ctx, "User not found"),
+		})
+		return
+	}
+	if errors.Is(err, postgres.ErrUsernameTaken) {
+		ctx.JSON(http.StatusConflict, gin.H{
+			"Error": h.t(ctx, err.Error()),
+		})
+		return
+	}
+	if err != nil {
+		h.Logger.Error("Error changing username", "error", err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": h.t(ctx, "Failed to change username")})
+		return
+	}
+
+	if old != name {
+		h.audit(ctx, audit.ActionUsernameChange, "user", userId, gin.H{"username": old}, gin.H{"username": name})
+	}
+
+	ctx.JSON(http.StatusOK, &pb.ChangeUsernameResponse{
+		Message:     h.t(ctx, "Username changed successfully"),
+		OldUsername: old,
+		Username:    name,
+	})
+}
+
+// ChangeUsernameHandler changes the current user's username
+// @Summary Change my username
+// @Description Change the username in the account and profile. The old name stays reserved for USERNAME_COOLDOWN_DAYS and still resolves in profile lookups. Refresh the token to get the new name in claims.
+// @Tags Auth
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param username body models.ChangeUsernameRequest true "New username"
+// @Success 200 {object} auth_service.ChangeUsernameResponse
+// @Failure 400 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /auth/me/username [put]
+func (h *Handler) ChangeUsernameHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling ChangeUsernameHandler request")
+
+	h.changeUsername(ctx, ctx.GetString("user_id"), profile.ChannelAPI)
+}
+
+// AdminChangeUsernameHandler changes any user's username
+// @Summary Change username
+// @Description Change a user's username, e.g. to replace an offensive one
+// @Tags Admin
+// @Accept json
+// @Security ApiKeyAuth
+// @Produce json
+// @Param user-id path string true "User ID"
+// @Param username body models.ChangeUsernameRequest true "New username"
+// @Success 200 {object} auth_service.ChangeUsernameResponse
+// @Failure 400 {object} models.Errors
+// @Failure 404 {object} models.Errors
+// @Failure 409 {object} models.Errors
+// @Failure 500 {object} models.Errors
+// @Router /admin/users/{user-id}/username [put]
+func (h *Handler) AdminChangeUsernameHandler(ctx *gin.Context) {
+	h.Logger.Info("Handling AdminChangeUsernameHandler request")
+
+	h.changeUsername(ctx, ctx.Param("user-id"), profile.ChannelAdmin)
+}
+
+// usernameReleased nom cooldown ichida boshqa foydalanuvchi tomonidan bo'shatilganmi
+func (h *Handler) usernameReleased(name string) (bool, error) {
+	return h.UserRepo.UsernameReleased(name, "", time.Duration(h.Config.USERNAME_COOLDOWN_DAYS)*24*time.Hour)
+}
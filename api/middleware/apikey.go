@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"auth-service/auth/apikey"
+	"auth-service/logs"
+	"auth-service/models"
+	"auth-service/storage/postgres"
+	"database/sql"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIKeyOrAuthMiddleware X-API-Key yoki Bearer tokenni qabul qiladi. API
+// kalit bilan kelganda user_id bo'sh, restaurant_id va permissions to'ldiriladi.
+func APIKeyOrAuthMiddleware(sessions *postgres.SessionRepo, apiKeys *postgres.APIKeyRepo) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(apikey.Header)
+		if key == "" {
+			if authenticateToken(c, sessions) {
+				c.Next()
+			}
+			return
+		}
+
+		stored, err := apiKeys.Authenticate(apikey.Hash(key))
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": tr(c, "Invalid API key")})
+			c.Abort()
+			return
+		}
+		if err != nil {
+			logs.Logger.Error("Error checking API key", "error", err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": tr(c, "Failed to check API key")})
+			c.Abort()
+			return
+		}
+
+		c.Set("api_key_id", stored.Id)
+		c.Set("restaurant_id", stored.RestaurantId)
+		c.Set("permissions", stored.Permissions)
+
+		c.Next()
+	}
+}
+
+// RequirePermission API kalitida ruxsat bo'lishini talab qiladi. Token
+// bilan kelgan so'rovlar o'tkazib yuboriladi.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("api_key_id") != "" && !slices.Contains(c.GetStringSlice("permissions"), permission) {
+			c.JSON(http.StatusForbidden, gin.H{"error": tr(c, "API key lacks permission %s", permission)})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RestaurantOwnerMiddleware :restaurant-id egasi yoki admin bo'lishini talab qiladi
+func RestaurantOwnerMiddleware(restaurants *postgres.RestaurantRepo) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("role") == models.RoleAdmin && c.GetString("actor_id") == "" {
+			c.Next()
+			return
+		}
+
+		role, err := restaurants.MemberRole(c.Param("restaurant-id"), c.GetString("user_id"))
+		if err != nil {
+			logs.Logger.Error("Error checking restaurant membership", "error", err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": tr(c, "Failed to check restaurant membership")})
+			c.Abort()
+			return
+		}
+		if role != postgres.RestaurantRoleOwner {
+			c.JSON(http.StatusForbidden, gin.H{"error": tr(c, "Restaurant owner access required")})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RestaurantMemberMiddleware :restaurant-id xodimi yoki shu restoranning API
+// kaliti bo'lishini talab qiladi. Adminlar ham a'zo bo'lishi kerak.
+func RestaurantMemberMiddleware(restaurants *postgres.RestaurantRepo) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("api_key_id") != "" {
+			if c.GetString("restaurant_id") != c.Param("restaurant-id") {
+				c.JSON(http.StatusForbidden, gin.H{"error": tr(c, "API key belongs to another restaurant")})
+				c.Abort()
+				return
+			}
+			c.Next()
+			return
+		}
+
+		role, err := restaurants.MemberRole(c.Param("restaurant-id"), c.GetString("user_id"))
+		if err != nil {
+			logs.Logger.Error("Error checking restaurant membership", "error", err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": tr(c, "Failed to check restaurant membership")})
+			c.Abort()
+			return
+		}
+		if role == "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": tr(c, "Restaurant membership required")})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
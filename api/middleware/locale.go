@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"auth-service/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware javob tilini Accept-Language bo'yicha tanlaydi.
+// AuthMiddleware uni token dagi locale bilan almashtiradi.
+func LocaleMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setLocale(c, i18n.Resolve(i18n.Negotiate(c.GetHeader("Accept-Language"))))
+		c.Next()
+	}
+}
+
+func setLocale(c *gin.Context, locale string) {
+	locale = i18n.Resolve(locale)
+	c.Set("locale", locale)
+	c.Header("Content-Language", locale)
+}
+
+// tr xabarni so'rov tiliga tarjima qiladi
+func tr(c *gin.Context, msg string, args ...interface{}) string {
+	return i18n.T(c.GetString("locale"), msg, args...)
+}
@@ -1,8 +1,15 @@
 package middleware
 
 import (
+	"auth-service/audit"
+	"auth-service/auth/lifecycle"
 	"auth-service/auth/token"
 	"auth-service/logs"
+	"auth-service/models"
+	"auth-service/storage/postgres"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"time"
@@ -10,31 +17,144 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func AuthMiddleware() gin.HandlerFunc {
+func AuthMiddleware(sessions *postgres.SessionRepo) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
-			c.Abort()
+		if !authenticateToken(c, sessions) {
 			return
 		}
 
-		claims, err := token.ExtractClaim(authHeader)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		c.Next()
+	}
+}
+
+// authenticateToken tokenni va sessiyani tekshiradi. Xato bo'lsa javob
+// yozib so'rovni to'xtatadi va false qaytaradi.
+func authenticateToken(c *gin.Context, sessions *postgres.SessionRepo) bool {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": tr(c, "Authorization header is required")})
+		c.Abort()
+		return false
+	}
+
+	claims, err := token.ExtractClaim(authHeader)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": tr(c, "Invalid token")})
+		c.Abort()
+		return false
+	}
+
+	if claims.ExpiresAt < time.Now().Unix() {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": tr(c, "Token has expired")})
+		c.Abort()
+		return false
+	}
+
+	status, err := sessions.Touch(claims.SessionId)
+	if err != nil {
+		logs.Logger.Error("Error checking session", "error", err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": tr(c, "Failed to check session")})
+		c.Abort()
+		return false
+	}
+	if status == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": tr(c, "Session has been revoked")})
+		c.Abort()
+		return false
+	}
+	if status != lifecycle.StatusActive {
+		c.JSON(http.StatusForbidden, gin.H{"error": tr(c, lifecycle.Message(status)), "code": lifecycle.Code(status)})
+		c.Abort()
+		return false
+	}
+
+	// Claimsdan ma'lumotlarni kontekstga qo'shish
+	c.Set("user_id", claims.UserId)
+	c.Set("username", claims.Username)
+	c.Set("user_email", claims.Email)
+	c.Set("session_id", claims.SessionId)
+	c.Set("role", claims.Role)
+	c.Set("timezone", claims.Timezone)
+	// Profilda tanlangan til Accept-Language dan ustun
+	if claims.Locale != "" {
+		setLocale(c, claims.Locale)
+	}
+	if claims.Act != nil {
+		c.Set("actor_id", claims.Act.Sub)
+	}
+
+	return true
+}
+
+// AdminMiddleware AuthMiddleware dan keyin ishlatiladi
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("role") != models.RoleAdmin || c.GetString("actor_id") != "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": tr(c, "Admin access required")})
+			c.Abort()
 			return
 		}
 
-		if claims.ExpiresAt < time.Now().Unix() {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has expired"})
+		c.Next()
+	}
+}
+
+// DenyImpersonation parol almashtirish kabi amallarni support tokeni bilan
+// bajarishni taqiqlaydi
+func DenyImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetString("actor_id") != "" {
+			c.JSON(http.StatusForbidden, gin.H{"error": tr(c, "This action is not allowed while impersonating a user")})
 			c.Abort()
 			return
 		}
 
-		// Claimsdan ma'lumotlarni kontekstga qo'shish
-		c.Set("user_id", claims.UserId)
-		c.Set("username", claims.Username)
-		c.Set("user_email", claims.Email)
+		c.Next()
+	}
+}
+
+// ImpersonationAudit support tokeni bilan qilingan har bir so'rovni audit
+// jurnaliga yozadi
+func ImpersonationAudit(auditRepo *postgres.AuditRepo) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		actorId := c.GetString("actor_id")
+		if actorId == "" {
+			return
+		}
+
+		after, _ := json.Marshal(gin.H{
+			"method": c.Request.Method,
+			"path":   c.FullPath(),
+			"status": c.Writer.Status(),
+		})
+		err := auditRepo.Append(&audit.Entry{
+			ActorId:    actorId,
+			Action:     audit.ActionImpersonationAction,
+			TargetType: "user",
+			TargetId:   c.GetString("user_id"),
+			After:      string(after),
+			RequestId:  c.GetString("request_id"),
+		})
+		if err != nil {
+			logs.Logger.Error("Error writing impersonation audit log", "error", err.Error())
+		}
+	}
+}
+
+// RequestIDMiddleware X-Request-ID ni qabul qiladi yoki yangisini yaratadi
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader("X-Request-ID")
+		if id == "" || len(id) > 64 {
+			b := make([]byte, 16)
+			rand.Read(b)
+			id = hex.EncodeToString(b)
+		}
+
+		c.Set("request_id", id)
+		c.Header("X-Request-ID", id)
 
 		c.Next()
 	}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"auth-service/auth/token"
+	"auth-service/logs"
+	"auth-service/ratelimit"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimit rule bo'yicha so'rovlarni cheklaydi. Limitdan oshganda 429 va
+// Retry-After qaytaradi. Store ishlamay qolsa so'rov o'tkazib yuboriladi.
+func RateLimit(limiter *ratelimit.Limiter, rule ratelimit.Rule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		res, err := limiter.Allow(c.Request.Context(), rule, rateLimitKey(c, rule.Key))
+		if err != nil {
+			logs.Logger.Error("Rate limit store error", "rule", rule.Name, "error", err.Error())
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(res.ResetAt.Unix(), 10))
+
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ratelimit.RetryAfterSeconds(res)))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": tr(c, "Too many requests")})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitKey kalitni topa olmasa IP manzilga qaytadi. KeyClientID
+// autentifikatsiyadan keyin ishlaydi: API kalit yoki token egasi bo'yicha.
+func rateLimitKey(c *gin.Context, key ratelimit.KeyType) string {
+	switch key {
+	case ratelimit.KeyUserID:
+		if id := c.GetString("user_id"); id != "" {
+			return "user:" + id
+		}
+		if claims, err := token.ExtractClaim(c.GetHeader("Authorization")); err == nil && claims.UserId != "" {
+			return "user:" + claims.UserId
+		}
+		// /auth/refresh_token refresh tokeni bilan keladi
+		if claims, err := token.ExtractRefreshClaim(c.GetHeader("Authorization")); err == nil && claims.UserId != "" {
+			return "user:" + claims.UserId
+		}
+	case ratelimit.KeyClientID:
+		if id := c.GetString("api_key_id"); id != "" {
+			return "apikey:" + id
+		}
+		if id := c.GetString("user_id"); id != "" {
+			return "user:" + id
+		}
+	}
+
+	return "ip:" + c.ClientIP()
+}
@@ -4,6 +4,10 @@ import (
 	_ "auth-service/api/docs"
 	"auth-service/api/handler"
 	"auth-service/api/middleware"
+	"auth-service/auth/apikey"
+	"auth-service/config"
+	"auth-service/ratelimit"
+	"time"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -19,17 +23,163 @@ import (
 // @in header
 // @name Authorization
 // @schemes http
-func Routes(handle *handler.Handler) *gin.Engine {
+func Routes(handle *handler.Handler, limiter *ratelimit.Limiter) *gin.Engine {
 	router := gin.Default()
 
+	// X-Forwarded-For faqat ishonchli proxylardan olinadi, aks holda
+	// ClientIP ulanish manzili
+	if err := router.SetTrustedProxies(config.Load().TRUSTED_PROXIES); err != nil {
+		panic(err)
+	}
+
 	// Swagger endpointini sozlash
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	router.Use(middleware.RequestIDMiddleware())
 	router.Use(middleware.LoggerMiddleware())
+	router.Use(middleware.LocaleMiddleware())
+
+	router.POST("auth/register", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_register", Algorithm: ratelimit.SlidingWindow, Limit: 5, Window: time.Hour, Key: ratelimit.KeyIP,
+	}), handle.RegisterHandler)
+	router.POST("auth/login", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_login", Algorithm: ratelimit.TokenBucket, Limit: 10, Window: time.Minute, Key: ratelimit.KeyIP,
+	}), handle.LoginHandler)
+	router.POST("auth/login/verify", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_login_verify", Algorithm: ratelimit.TokenBucket, Limit: 10, Window: time.Minute, Key: ratelimit.KeyIP,
+	}), handle.VerifyLoginHandler)
+	router.POST("auth/login/consent", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_login_consent", Algorithm: ratelimit.TokenBucket, Limit: 10, Window: time.Minute, Key: ratelimit.KeyIP,
+	}), handle.LoginConsentHandler)
+	router.GET("auth/legal-documents", handle.LegalDocumentsHandler)
+	router.POST("auth/login-alerts/deny", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_login_deny", Algorithm: ratelimit.SlidingWindow, Limit: 10, Window: time.Hour, Key: ratelimit.KeyIP,
+	}), handle.DenyLoginHandler)
+	router.POST("auth/restore", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_restore", Algorithm: ratelimit.TokenBucket, Limit: 10, Window: time.Minute, Key: ratelimit.KeyIP,
+	}), handle.RestoreAccountHandler)
+	router.GET("auth/exports/:id/download", handle.DownloadDataExportHandler)
+	router.GET("media/*key", handle.ServeMediaHandler)
+	router.POST("auth/email/confirm", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_email_confirm", Algorithm: ratelimit.TokenBucket, Limit: 10, Window: time.Minute, Key: ratelimit.KeyIP,
+	}), handle.ConfirmEmailChangeHandler)
+	router.POST("auth/email/undo", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_email_undo", Algorithm: ratelimit.TokenBucket, Limit: 10, Window: time.Minute, Key: ratelimit.KeyIP,
+	}), handle.UndoEmailChangeHandler)
+	router.POST("auth/email/verify", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_email_verify", Algorithm: ratelimit.TokenBucket, Limit: 10, Window: time.Minute, Key: ratelimit.KeyIP,
+	}), handle.VerifyEmailHandler)
+	router.POST("auth/email/verify/resend", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_email_verify_resend", Algorithm: ratelimit.SlidingWindow, Limit: 5, Window: time.Hour, Key: ratelimit.KeyIP,
+	}), handle.ResendEmailVerificationHandler)
+	router.GET("auth/refresh_token", middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_refresh_token", Algorithm: ratelimit.TokenBucket, Limit: 30, Window: time.Minute, Key: ratelimit.KeyUserID,
+	}), handle.RefreshToken)
+
+	auth := router.Group("auth", middleware.AuthMiddleware(handle.SessionRepo), middleware.ImpersonationAudit(handle.AuditRepo))
+	auth.GET("sessions", handle.ListSessionsHandler)
+	auth.DELETE("sessions/:id", middleware.DenyImpersonation(), handle.RevokeSessionHandler)
+	auth.DELETE("sessions", middleware.DenyImpersonation(), handle.RevokeOtherSessionsHandler)
+	auth.GET("me/security-events", handle.SecurityEventsHandler)
+	auth.POST("me/deactivate", middleware.DenyImpersonation(), handle.DeactivateAccountHandler)
+	auth.DELETE("me", middleware.DenyImpersonation(), handle.DeleteAccountHandler)
+	auth.POST("me/export", middleware.DenyImpersonation(), middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_data_export", Algorithm: ratelimit.SlidingWindow, Limit: 3, Window: 24 * time.Hour, Key: ratelimit.KeyUserID,
+	}), handle.RequestDataExportHandler)
+	auth.GET("me/exports", handle.ListDataExportsHandler)
+	auth.GET("me/profile", handle.GetMyProfileHandler)
+	auth.PUT("me/profile", handle.UpdateMyProfileHandler)
+	auth.PATCH("me/profile", handle.PatchProfileHandler)
+	auth.POST("me/logout", handle.LogoutHandler)
+	auth.GET("me/consents", handle.GetMyConsentsHandler)
+	auth.POST("me/consents", middleware.DenyImpersonation(), handle.AcceptConsentHandler)
+	auth.GET("me/push-devices", handle.ListPushDevicesHandler)
+	auth.POST("me/push-devices", middleware.DenyImpersonation(), handle.RegisterPushDeviceHandler)
+	auth.PUT("me/push-devices/:id", middleware.DenyImpersonation(), handle.RefreshPushDeviceHandler)
+	auth.DELETE("me/push-devices/:id", handle.RemovePushDeviceHandler)
+	auth.GET("me/preferences/dining", handle.GetDiningPreferencesHandler)
+	auth.PUT("me/preferences/dining", handle.UpdateDiningPreferencesHandler)
+	auth.GET("preferences/dining/vocabulary", handle.DiningVocabularyHandler)
+	auth.GET("me/preferences/notifications", handle.GetNotificationPreferencesHandler)
+	auth.PUT("me/preferences/notifications", handle.UpdateNotificationPreferencesHandler)
+	auth.DELETE("me/preferences/notifications", handle.ResetNotificationPreferencesHandler)
+	auth.GET("me/sharing", handle.GetSharingSettingsHandler)
+	auth.PUT("me/sharing", middleware.DenyImpersonation(), handle.UpdateSharingSettingsHandler)
+	auth.GET("me/sharing/access-log", handle.ListGuestAccessHandler)
+	auth.PUT("me/avatar", middleware.DenyImpersonation(), middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_upload_avatar", Algorithm: ratelimit.SlidingWindow, Limit: 10, Window: time.Hour, Key: ratelimit.KeyUserID,
+	}), handle.UploadAvatarHandler)
+	auth.DELETE("me/avatar", middleware.DenyImpersonation(), handle.DeleteAvatarHandler)
+	auth.PUT("me/username", middleware.DenyImpersonation(), middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_change_username", Algorithm: ratelimit.SlidingWindow, Limit: 5, Window: 24 * time.Hour, Key: ratelimit.KeyUserID,
+	}), handle.ChangeUsernameHandler)
+	auth.POST("me/email", middleware.DenyImpersonation(), middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_change_email", Algorithm: ratelimit.SlidingWindow, Limit: 5, Window: time.Hour, Key: ratelimit.KeyUserID,
+	}), handle.ChangeEmailHandler)
+	auth.POST("me/phone/verify", middleware.DenyImpersonation(), middleware.RateLimit(limiter, ratelimit.Rule{
+		Name: "http_phone_verify", Algorithm: ratelimit.SlidingWindow, Limit: 5, Window: time.Hour, Key: ratelimit.KeyUserID,
+	}), handle.SendPhoneCodeHandler)
+	auth.POST("me/phone/confirm", middleware.DenyImpersonation(), handle.ConfirmPhoneHandler)
+	auth.GET("me/exports/:id", handle.GetDataExportHandler)
+	auth.POST("impersonation/stop", handle.StopImpersonationHandler)
+
+	// ImpersonationAudit foydalanuvchi JWT qabul qiladigan har bir guruhda, rad etilgan urinishlar ham yoziladi
+	admin := router.Group("admin", middleware.AuthMiddleware(handle.SessionRepo),
+		middleware.ImpersonationAudit(handle.AuditRepo),
+		middleware.AdminMiddleware())
+	admin.GET("login-events", handle.AdminLoginEventsHandler)
+	admin.GET("audit-logs", handle.AdminAuditLogsHandler)
+	admin.GET("audit-logs/verify", handle.AdminVerifyAuditLogHandler)
+	admin.GET("users", handle.SearchUsersHandler)
+	admin.GET("users/:user-id", handle.GetUserDetailsHandler)
+	admin.GET("users/:user-id/status", handle.GetAccountStatusHandler)
+	admin.POST("users/:user-id/suspend", handle.SuspendUserHandler)
+	admin.POST("users/:user-id/reinstate", handle.ReinstateUserHandler)
+	admin.PUT("users/:user-id/role", handle.SetUserRoleHandler)
+	admin.GET("users/:user-id/profile", handle.AdminGetUserProfileHandler)
+	admin.PUT("users/:user-id/profile", handle.UpdateUserProfile)
+	admin.PATCH("users/:user-id/profile", handle.AdminPatchProfileHandler)
+	admin.POST("users/:user-id/logout", handle.AdminLogoutUserHandler)
+	admin.GET("users/:user-id/profile/history", handle.AdminProfileHistoryHandler)
+	admin.GET("users/:user-id/profile/as-of", handle.AdminProfileAsOfHandler)
+	admin.POST("users/:user-id/profile/revert", handle.AdminRevertProfileHandler)
+	admin.PUT("users/:user-id/username", handle.AdminChangeUsernameHandler)
+	admin.GET("users/:user-id/preferences/dining", handle.AdminGetDiningPreferencesHandler)
+	admin.GET("users/:user-id/preferences/notifications", handle.AdminGetNotificationPreferencesHandler)
+	admin.DELETE("users/:user-id", handle.AdminDeleteUserHandler)
+	admin.POST("users/:user-id/impersonate", handle.ImpersonateHandler)
+	admin.POST("legal-documents", handle.AdminPublishLegalDocumentHandler)
+	admin.GET("legal-documents", handle.AdminListLegalDocumentsHandler)
+	admin.GET("legal-documents/report", handle.AdminConsentReportHandler)
+	admin.PUT("restaurants/:restaurant-id/members/:user-id", handle.SetRestaurantMemberHandler)
+	admin.DELETE("restaurants/:restaurant-id/members/:user-id", handle.RemoveRestaurantMemberHandler)
+
+	keys := router.Group("restaurants/:restaurant-id/api-keys",
+		middleware.AuthMiddleware(handle.SessionRepo),
+		middleware.ImpersonationAudit(handle.AuditRepo),
+		middleware.DenyImpersonation(),
+		middleware.RestaurantOwnerMiddleware(handle.RestaurantRepo))
+	keys.POST("", handle.CreateAPIKeyHandler)
+	keys.GET("", handle.ListAPIKeysHandler)
+	keys.GET(":id", handle.GetAPIKeyHandler)
+	keys.PUT(":id", handle.UpdateAPIKeyHandler)
+	keys.DELETE(":id", handle.RevokeAPIKeyHandler)
+
+	guests := router.Group("restaurants/:restaurant-id/guests", middleware.APIKeyOrAuthMiddleware(handle.SessionRepo, handle.APIKeyRepo),
+		middleware.ImpersonationAudit(handle.AuditRepo),
+		middleware.RateLimit(limiter, ratelimit.Rule{
+			Name: "http_guests", Algorithm: ratelimit.TokenBucket, Limit: 600, Window: time.Minute, Key: ratelimit.KeyClientID,
+		}),
+		middleware.RequirePermission(apikey.PermissionGuestsRead),
+		middleware.RestaurantMemberMiddleware(handle.RestaurantRepo))
+	guests.GET(":user-id", handle.GetGuestProfileHandler)
 
-	router.POST("auth/register", handle.RegisterHandler)
-	router.POST("auth/login", handle.LoginHandler)
-	router.GET("auth/refresh_token", handle.RefreshToken)
+	partner := router.Group("partner", middleware.APIKeyOrAuthMiddleware(handle.SessionRepo, handle.APIKeyRepo),
+		middleware.ImpersonationAudit(handle.AuditRepo),
+		middleware.RateLimit(limiter, ratelimit.Rule{
+			Name: "http_partner", Algorithm: ratelimit.TokenBucket, Limit: 600, Window: time.Minute, Key: ratelimit.KeyClientID,
+		}))
+	partner.GET("whoami", handle.PartnerWhoAmIHandler)
 
 	return router
 }
@@ -0,0 +1,185 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ActionRoleGrant      = "user.role_grant"
+	ActionProfileUpdate  = "user.profile_update"
+	ActionAccountDelete  = "user.delete"
+	ActionAccountPurge   = "user.purge"
+	ActionPasswordReset  = "user.password_reset"
+	ActionStatusChange   = "user.status_change"
+	ActionEmailChange    = "user.email_change"
+	ActionUsernameChange = "user.username_change"
+	ActionForceLogout    = "user.force_logout"
+	ActionPhoneVerify    = "user.phone_verify"
+	ActionEmailVerify    = "user.email_verify"
+
+	ActionImpersonationStart  = "impersonation.start"
+	ActionImpersonationStop   = "impersonation.stop"
+	ActionImpersonationAction = "impersonation.action"
+
+	ActionAPIKeyCreate     = "api_key.create"
+	ActionAPIKeyUpdate     = "api_key.update"
+	ActionAPIKeyRevoke     = "api_key.revoke"
+	ActionRestaurantMember = "restaurant.member"
+
+	ActionLegalDocumentPublish = "legal_document.publish"
+
+	// ActionGuestProfileAccess restoran dinerning mehmon profilini ko'rdi
+	ActionGuestProfileAccess = "guest.profile_access"
+)
+
+// PersonalFields before/after diff laridagi shaxsiy ma'lumot kalitlari.
+// Foydalanuvchi purge qilinganda ularning qiymatlari Redacted bilan almashtiriladi.
+var PersonalFields = []string{
+	"fullname",
+	"username",
+	"email",
+	"phone_number",
+	"address",
+	"date_of_birth",
+	"avatar_url",
+	"avatar",
+	"dietary_restrictions",
+	"allergies",
+	"ip",
+	"user_agent",
+	"identifier",
+	"device_name",
+}
+
+// Redacted o'chirilgan shaxsiy ma'lumot o'rniga yoziladigan qiymat
+const Redacted = "[redacted]"
+
+// Entry audit jurnalining bitta yozuvi. Hash oldingi yozuv hashi va shu
+// yozuv maydonlaridan hisoblanadi, shuning uchun bitta yozuvni o'zgartirish
+// undan keyingi butun zanjirni buzadi. before/after hashga PayloadHash orqali
+// kiradi: purge shaxsiy ma'lumotlarni o'chirgandan keyin ham qolgan maydonlar
+// va payload digesti tekshiriladi.
+type Entry struct {
+	Id         int64
+	ActorId    string
+	Action     string
+	TargetType string
+	TargetId   string
+	Before     string
+	After      string
+	RequestId  string
+	CreatedAt  time.Time
+	PrevHash   string
+	Hash       string
+
+	// PayloadHash bo'sh bo'lsa yozuv payload_hash ustunidan oldin yozilgan,
+	// uning hashi before/after ning o'zidan hisoblanadi
+	PayloadHash string
+}
+
+func (e *Entry) ComputeHash() string {
+	payload := []string{Canonical(e.Before), Canonical(e.After)}
+	if e.PayloadHash != "" {
+		payload = []string{e.PayloadHash}
+	}
+
+	fields := []string{e.PrevHash, e.ActorId, e.Action, e.TargetType, e.TargetId}
+	fields = append(fields, payload...)
+	fields = append(fields, e.RequestId, strconv.FormatInt(e.CreatedAt.UTC().UnixMicro(), 10))
+
+	return digest(fields)
+}
+
+// ComputePayloadHash before va after ning digesti
+func (e *Entry) ComputePayloadHash() string {
+	return digest([]string{Canonical(e.Before), Canonical(e.After)})
+}
+
+func digest(fields []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(fields, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Canonical JSON ni kalitlari tartiblangan ko'rinishga keltiradi. Postgres
+// JSONB bo'shliqlar va kalitlar tartibini o'zgartirgani uchun hash shu
+// ko'rinishdan hisoblanadi.
+func Canonical(raw string) string {
+	if raw == "" {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		return raw
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return raw
+	}
+	return string(b)
+}
+
+// Diff before va after dan faqat o'zgargan maydonlarni JSON ko'rinishida
+// qaytaradi. nil qiymat bo'sh tomon sifatida qabul qilinadi.
+func Diff(before, after interface{}) (string, string, error) {
+	b, err := toMap(before)
+	if err != nil {
+		return "", "", err
+	}
+	a, err := toMap(after)
+	if err != nil {
+		return "", "", err
+	}
+
+	changedBefore := map[string]interface{}{}
+	changedAfter := map[string]interface{}{}
+	for k, v := range b {
+		if av, ok := a[k]; !ok || !equal(v, av) {
+			changedBefore[k] = v
+		}
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || !equal(v, bv) {
+			changedAfter[k] = v
+		}
+	}
+
+	return marshal(changedBefore, before == nil), marshal(changedAfter, after == nil), nil
+}
+
+func toMap(v interface{}) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	if v == nil {
+		return m, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return m, json.Unmarshal(b, &m)
+}
+
+func equal(a, b interface{}) bool {
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+	return string(x) == string(y)
+}
+
+func marshal(m map[string]interface{}, empty bool) string {
+	if empty {
+		return ""
+	}
+	b, _ := json.Marshal(m)
+	return string(b)
+}
+
+// APIKeyActor API kalit bilan qilingan amallar uchun actor_id
+func APIKeyActor(apiKeyId string) string {
+	return "api_key:" + apiKeyId
+}
@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeHash(t *testing.T) {
+	entry := Entry{
+		ActorId:   "admin",
+		Action:    ActionRoleGrant,
+		TargetId:  "fc27aae7-e777-45f1-9431-f00c31dfdea0",
+		Before:    `{"role":"user"}`,
+		After:     `{"role":"admin"}`,
+		CreatedAt: time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC),
+		PrevHash:  "0",
+	}
+	hash := entry.ComputeHash()
+
+	// Postgres JSONB ko'rinishi hashni o'zgartirmasligi kerak
+	entry.After = `{"role": "admin"}`
+	assert.Equal(t, hash, entry.ComputeHash())
+
+	entry.After = `{"role": "user"}`
+	assert.NotEqual(t, hash, entry.ComputeHash())
+}
+
+func TestComputeHashWithPayloadHash(t *testing.T) {
+	entry := Entry{
+		ActorId:   "admin",
+		Action:    ActionProfileUpdate,
+		TargetId:  "fc27aae7-e777-45f1-9431-f00c31dfdea0",
+		Before:    `{"fullname":"Ali"}`,
+		After:     `{"fullname":"Vali"}`,
+		CreatedAt: time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC),
+		PrevHash:  "0",
+	}
+	entry.PayloadHash = entry.ComputePayloadHash()
+	hash := entry.ComputeHash()
+
+	// Redaction payloadni o'zgartiradi, lekin hash saqlangan digestdan hisoblanadi
+	entry.Before = `{"fullname":"[redacted]"}`
+	entry.After = `{"fullname":"[redacted]"}`
+	assert.Equal(t, hash, entry.ComputeHash())
+	assert.NotEqual(t, entry.PayloadHash, entry.ComputePayloadHash())
+
+	entry.ActorId = "support"
+	assert.NotEqual(t, hash, entry.ComputeHash())
+}
+
+func TestDiff(t *testing.T) {
+	before, after, err := Diff(
+		map[string]string{"fullname": "Ali", "address": "Tashkent"},
+		map[string]string{"fullname": "Ali", "address": "Samarkand"},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"address":"Tashkent"}`, before)
+	assert.Equal(t, `{"address":"Samarkand"}`, after)
+
+	before, after, err = Diff(nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, before)
+	assert.Empty(t, after)
+}
@@ -0,0 +1,68 @@
+package apikey
+
+import (
+	"auth-service/auth/token"
+	"crypto/rand"
+	"encoding/base64"
+	"slices"
+	"strings"
+)
+
+// Prefix kalitlarni loglarda va kod skanerlarida tanib olish uchun
+const Prefix = "dr_live_"
+
+// Header HTTP header va gRPC metadata kaliti
+const Header = "X-API-Key"
+
+const (
+	PermissionReservationsRead  = "reservations:read"
+	PermissionReservationsWrite = "reservations:write"
+	PermissionGuestsRead        = "guests:read"
+	PermissionPaymentsRead      = "payments:read"
+	// PermissionNotificationsRead bron xizmati eslatma yuborishdan oldin
+	// dinerning sozlamalarini o'qiydi
+	PermissionNotificationsRead = "notifications:read"
+	// PermissionUsersRead boshqa servislar GetUserProfile bilan foydalanuvchini o'qiydi
+	PermissionUsersRead = "users:read"
+)
+
+var Permissions = []string{
+	PermissionReservationsRead,
+	PermissionReservationsWrite,
+	PermissionGuestsRead,
+	PermissionPaymentsRead,
+	PermissionNotificationsRead,
+	PermissionUsersRead,
+}
+
+// Generate yangi kalit, uning ko'rsatiladigan boshi va bazadagi hashini
+// qaytaradi. To'liq kalit faqat bir marta ko'rsatiladi.
+func Generate() (key, displayPrefix, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", "", err
+	}
+
+	key = Prefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, key[:len(Prefix)+6], Hash(key), nil
+}
+
+func Hash(key string) string {
+	return token.HashOpaque(key)
+}
+
+func IsKey(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+func ValidPermissions(permissions []string) bool {
+	if len(permissions) == 0 {
+		return false
+	}
+	for _, p := range permissions {
+		if !slices.Contains(Permissions, p) {
+			return false
+		}
+	}
+	return true
+}
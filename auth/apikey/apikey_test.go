@@ -0,0 +1,27 @@
+package apikey
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	key, prefix, hash, err := Generate()
+	assert.NoError(t, err)
+	assert.True(t, IsKey(key))
+	assert.True(t, strings.HasPrefix(key, prefix))
+	assert.Equal(t, Hash(key), hash)
+	assert.NotEqual(t, key, hash)
+
+	other, _, _, err := Generate()
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestValidPermissions(t *testing.T) {
+	assert.True(t, ValidPermissions([]string{PermissionReservationsRead, PermissionGuestsRead}))
+	assert.False(t, ValidPermissions(nil))
+	assert.False(t, ValidPermissions([]string{PermissionReservationsRead, "users:delete"}))
+}
@@ -0,0 +1,33 @@
+package device
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strings"
+)
+
+// Fingerprint qurilmani user agent, qurilma nomi va ilova yuborgan
+// X-Device-ID bo'yicha aniqlaydi
+func Fingerprint(userAgent, deviceName, deviceId string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		strings.TrimSpace(userAgent),
+		strings.ToLower(strings.TrimSpace(deviceName)),
+		strings.TrimSpace(deviceId),
+	}, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+// IPRange IPv4 uchun /24, IPv6 uchun /48 tarmog'ini qaytaradi, shunda
+// provayder ichida IP almashishi yangi joy deb hisoblanmaydi
+func IPRange(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ip
+	}
+
+	if v4 := parsed.To4(); v4 != nil {
+		return (&net.IPNet{IP: v4.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}).String()
+	}
+	return (&net.IPNet{IP: parsed.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}).String()
+}
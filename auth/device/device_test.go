@@ -0,0 +1,20 @@
+package device
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPRange(t *testing.T) {
+	assert.Equal(t, "192.168.1.0/24", IPRange("192.168.1.17"))
+	assert.Equal(t, IPRange("10.0.0.1"), IPRange("10.0.0.254"))
+	assert.NotEqual(t, IPRange("10.0.0.1"), IPRange("10.0.1.1"))
+	assert.Equal(t, "2001:db8:abcd::/48", IPRange("2001:db8:abcd:12::1"))
+	assert.Equal(t, "unknown", IPRange("unknown"))
+}
+
+func TestFingerprint(t *testing.T) {
+	assert.Equal(t, Fingerprint("Mozilla/5.0", "iPhone", ""), Fingerprint("Mozilla/5.0 ", "iphone", ""))
+	assert.NotEqual(t, Fingerprint("Mozilla/5.0", "iPhone", ""), Fingerprint("Mozilla/5.0", "iPhone", "device-1"))
+}
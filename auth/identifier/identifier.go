@@ -0,0 +1,39 @@
+package identifier
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	KindEmail    = "email"
+	KindPhone    = "phone"
+	KindUsername = "username"
+)
+
+// phonePattern profile paketidagi bilan bir xil: saqlangan har bir raqam
+// bilan login qilish mumkin bo'lishi kerak
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+
+// NormalizeEmail emailni bo'shliqlarsiz va kichik harflarda qaytaradi
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NormalizePhone bo'shliq, chiziqcha va qavslarni olib tashlaydi
+func NormalizePhone(phone string) string {
+	return strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(strings.TrimSpace(phone))
+}
+
+// Kind login uchun kiritilgan qiymat email, telefon yoki username ekanini aniqlaydi
+func Kind(value string) string {
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.Contains(value, "@"):
+		return KindEmail
+	case phonePattern.MatchString(NormalizePhone(value)):
+		return KindPhone
+	default:
+		return KindUsername
+	}
+}
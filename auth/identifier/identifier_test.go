@@ -0,0 +1,19 @@
+package identifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKind(t *testing.T) {
+	assert.Equal(t, KindEmail, Kind(" Ali@Mail.com"))
+	assert.Equal(t, KindPhone, Kind("+998 93 995-57-26"))
+	assert.Equal(t, KindUsername, Kind("diyorbeknematov"))
+	assert.Equal(t, KindPhone, Kind("123-45-67"))
+	assert.Equal(t, KindUsername, Kind("12345"))
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	assert.Equal(t, "ali@mail.com", NormalizeEmail(" Ali@Mail.com "))
+}
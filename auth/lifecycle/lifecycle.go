@@ -0,0 +1,66 @@
+package lifecycle
+
+import "slices"
+
+const (
+	StatusPending     = "pending_verification"
+	StatusActive      = "active"
+	StatusSuspended   = "suspended"
+	StatusDeactivated = "deactivated"
+	// StatusPendingDeletion foydalanuvchi o'chirishni so'ragan, muddat
+	// tugaguncha hisobni tiklash mumkin
+	StatusPendingDeletion = "pending_deletion"
+	StatusDeleted         = "deleted"
+)
+
+// transitions har bir holatdan ruxsat etilgan keyingi holatlar.
+// deleted oxirgi holat, undan chiqib bo'lmaydi.
+var transitions = map[string][]string{
+	StatusPending:         {StatusActive, StatusDeleted},
+	StatusActive:          {StatusSuspended, StatusDeactivated, StatusPendingDeletion, StatusDeleted},
+	StatusSuspended:       {StatusActive, StatusDeleted},
+	StatusDeactivated:     {StatusActive, StatusPendingDeletion, StatusDeleted},
+	StatusPendingDeletion: {StatusActive, StatusDeleted},
+}
+
+func Valid(status string) bool {
+	_, ok := transitions[status]
+	return ok || status == StatusDeleted
+}
+
+func CanTransition(from, to string) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// Code faol bo'lmagan hisob uchun klientga qaytariladigan xato kodi
+func Code(status string) string {
+	switch status {
+	case StatusPending:
+		return "account_pending_verification"
+	case StatusSuspended:
+		return "account_suspended"
+	case StatusDeactivated:
+		return "account_deactivated"
+	case StatusPendingDeletion:
+		return "account_pending_deletion"
+	case StatusDeleted:
+		return "account_deleted"
+	}
+	return ""
+}
+
+func Message(status string) string {
+	switch status {
+	case StatusPending:
+		return "Account is waiting for verification"
+	case StatusSuspended:
+		return "Account is suspended"
+	case StatusDeactivated:
+		return "Account is deactivated"
+	case StatusPendingDeletion:
+		return "Account is scheduled for deletion, use /auth/restore to restore it"
+	case StatusDeleted:
+		return "Account has been deleted"
+	}
+	return ""
+}
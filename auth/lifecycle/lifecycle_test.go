@@ -0,0 +1,34 @@
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	assert.True(t, CanTransition(StatusPending, StatusActive))
+	assert.True(t, CanTransition(StatusActive, StatusSuspended))
+	assert.True(t, CanTransition(StatusSuspended, StatusActive))
+	assert.True(t, CanTransition(StatusDeactivated, StatusDeleted))
+	assert.True(t, CanTransition(StatusActive, StatusPendingDeletion))
+	assert.True(t, CanTransition(StatusPendingDeletion, StatusActive))
+
+	assert.False(t, CanTransition(StatusActive, StatusActive))
+	assert.False(t, CanTransition(StatusPending, StatusSuspended))
+	assert.False(t, CanTransition(StatusDeleted, StatusActive))
+	assert.False(t, CanTransition(StatusSuspended, StatusPendingDeletion))
+	assert.False(t, CanTransition("unknown", StatusActive))
+}
+
+func TestCode(t *testing.T) {
+	codes := map[string]bool{}
+	for _, s := range []string{StatusPending, StatusSuspended, StatusDeactivated, StatusPendingDeletion, StatusDeleted} {
+		assert.True(t, Valid(s))
+		assert.NotEmpty(t, Code(s))
+		codes[Code(s)] = true
+	}
+	assert.Len(t, codes, 5)
+	assert.Empty(t, Code(StatusActive))
+	assert.False(t, Valid("banned"))
+}
@@ -0,0 +1,44 @@
+package principal
+
+import (
+	"context"
+	"slices"
+)
+
+// Principal so'rov kim nomidan kelganini bildiradi: token orqali kirgan
+// foydalanuvchi yoki restoran API kaliti
+type Principal struct {
+	UserId    string
+	Username  string
+	Email     string
+	SessionId string
+	Role      string
+	Locale    string
+	Timezone  string
+	ActorId   string
+
+	APIKeyId     string
+	RestaurantId string
+	Permissions  []string
+}
+
+func (p *Principal) IsAPIKey() bool {
+	return p.APIKeyId != ""
+}
+
+// HasPermission API kalitida ruxsat borligini tekshiradi. Foydalanuvchi
+// tokenlari ruxsatlar bilan cheklanmaydi.
+func (p *Principal) HasPermission(permission string) bool {
+	return !p.IsAPIKey() || slices.Contains(p.Permissions, permission)
+}
+
+type contextKey struct{}
+
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(*Principal)
+	return p, ok
+}
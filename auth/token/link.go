@@ -0,0 +1,20 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+)
+
+// SignLink id va amal qilish muddati uchun HMAC imzo qaytaradi. Imzo
+// yuklab olish havolalarini login talab qilmasdan ochish uchun.
+func SignLink(secret, id string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(id + ":" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifyLink(secret, id string, expires int64, signature string) bool {
+	return hmac.Equal([]byte(SignLink(secret, id, expires)), []byte(signature))
+}
@@ -0,0 +1,16 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignLink(t *testing.T) {
+	sig := SignLink("secret", "export-1", 1700000000)
+
+	assert.True(t, VerifyLink("secret", "export-1", 1700000000, sig))
+	assert.False(t, VerifyLink("secret", "export-2", 1700000000, sig))
+	assert.False(t, VerifyLink("secret", "export-1", 1700000001, sig))
+	assert.False(t, VerifyLink("other", "export-1", 1700000000, sig))
+}
@@ -0,0 +1,36 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+)
+
+// GenerateOpaque havolalar uchun tasodifiy token va uning bazada saqlanadigan
+// hashini qaytaradi
+func GenerateOpaque() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+
+	plain := base64.RawURLEncoding.EncodeToString(b)
+	return plain, HashOpaque(plain), nil
+}
+
+func HashOpaque(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateCode 6 xonali bir martalik kod qaytaradi
+func GenerateCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
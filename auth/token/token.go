@@ -4,24 +4,54 @@ import (
 	"auth-service/config"
 	pb "auth-service/generated/auth_service"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
+// Token turlari (typ claim). Access va impersonation tokenlari ACCESS_TOKEN,
+// refresh tokeni REFRESH_TOKEN kaliti bilan imzolanadi va bir-birining
+// o'rnida ishlamaydi.
+const (
+	TypeAccess        = "access"
+	TypeRefresh       = "refresh"
+	TypeImpersonation = "impersonation"
+)
+
 type Claims struct {
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
+	Type      string `json:"typ"`
+	UserId    string `json:"user_id"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	SessionId string `json:"session_id"`
+	Role      string `json:"role"`
+	Locale    string `json:"locale,omitempty"`   // OIDC standart claim nomlari
+	Timezone  string `json:"zoneinfo,omitempty"` // IANA vaqt zonasi
+	Act       *Actor `json:"act,omitempty"`
 	jwt.StandardClaims
 }
 
+// Actor boshqa foydalanuvchi nomidan ishlayotgan support xodimi (RFC 8693 act claim)
+type Actor struct {
+	Sub string `json:"sub"`
+}
+
+// ImpersonationTTL support tokenining amal qilish muddati
+const ImpersonationTTL = 15 * time.Minute
+
 func GenerateAccessJWT(user *pb.LoginResponse) (string, error) {
 	cfg := config.Load()
 	claims := &Claims{
-		UserId:   user.UserId,
-		Username: user.Username,
-		Email:    user.Email,
+		Type:      TypeAccess,
+		UserId:    user.UserId,
+		Username:  user.Username,
+		Email:     user.Email,
+		SessionId: user.SessionId,
+		Role:      user.Role,
+		Locale:    user.Locale,
+		Timezone:  user.Timezone,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(20 * time.Minute).Unix(),
 			IssuedAt:  time.Now().Unix(),
@@ -35,9 +65,14 @@ func GenerateAccessJWT(user *pb.LoginResponse) (string, error) {
 func GenerateRefreshJWT(user *pb.LoginResponse) (string, error) {
 	cfg := config.Load()
 	claims := &Claims{
-		UserId:   user.UserId,
-		Username: user.Username,
-		Email:    user.Email,
+		Type:      TypeRefresh,
+		UserId:    user.UserId,
+		Username:  user.Username,
+		Email:     user.Email,
+		SessionId: user.SessionId,
+		Role:      user.Role,
+		Locale:    user.Locale,
+		Timezone:  user.Timezone,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(14 * 24 * time.Hour).Unix(),
 			IssuedAt:  time.Now().Unix(),
@@ -48,12 +83,51 @@ func GenerateRefreshJWT(user *pb.LoginResponse) (string, error) {
 	return accessToken.SignedString([]byte(cfg.REFRESH_TOKEN))
 }
 
-func ExtractClaim(tokenString string) (*Claims, error) {
+// GenerateImpersonationJWT support xodimi uchun qisqa muddatli, yangilab
+// bo'lmaydigan token yaratadi. act claim bu token bilan qilingan har bir
+// amalni xodimga bog'laydi.
+func GenerateImpersonationJWT(user *pb.LoginResponse, actorId string) (string, error) {
 	cfg := config.Load()
+	claims := &Claims{
+		Type:      TypeImpersonation,
+		UserId:    user.UserId,
+		Username:  user.Username,
+		Email:     user.Email,
+		SessionId: user.SessionId,
+		Role:      user.Role,
+		Locale:    user.Locale,
+		Timezone:  user.Timezone,
+		Act:       &Actor{Sub: actorId},
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(ImpersonationTTL).Unix(),
+			IssuedAt:  time.Now().Unix(),
+		},
+	}
+	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	return accessToken.SignedString([]byte(cfg.ACCESS_TOKEN))
+}
+
+// ExtractClaim access yoki impersonation tokenini tekshiradi. Refresh
+// tokeni bu yerda qabul qilinmaydi.
+func ExtractClaim(tokenString string) (*Claims, error) {
+	return parse(tokenString, config.Load().ACCESS_TOKEN, TypeAccess, TypeImpersonation)
+}
+
+// ExtractRefreshClaim faqat refresh tokenini qabul qiladi
+func ExtractRefreshClaim(tokenString string) (*Claims, error) {
+	return parse(tokenString, config.Load().REFRESH_TOKEN, TypeRefresh)
+}
+
+func parse(tokenString, key string, types ...string) (*Claims, error) {
 	claims := &Claims{}
 
+	tokenString = strings.TrimPrefix(tokenString, "Bearer ")
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(cfg.REFRESH_TOKEN), nil
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(key), nil
 	})
 
 	if err != nil {
@@ -64,5 +138,9 @@ func ExtractClaim(tokenString string) (*Claims, error) {
 		return nil, errors.New("invalid token")
 	}
 
+	if !slices.Contains(types, claims.Type) || (claims.Act != nil) != (claims.Type == TypeImpersonation) {
+		return nil, errors.New("invalid token type")
+	}
+
 	return claims, nil
 }
@@ -0,0 +1,57 @@
+package token
+
+import (
+	pb "auth-service/generated/auth_service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenTypes(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN", "access-secret")
+	t.Setenv("REFRESH_TOKEN", "refresh-secret")
+	user := &pb.LoginResponse{UserId: "u1", SessionId: "s1"}
+
+	access, err := GenerateAccessJWT(user)
+	require.NoError(t, err)
+	refresh, err := GenerateRefreshJWT(user)
+	require.NoError(t, err)
+	support, err := GenerateImpersonationJWT(user, "agent")
+	require.NoError(t, err)
+
+	claims, err := ExtractClaim("Bearer " + access)
+	require.NoError(t, err)
+	assert.Equal(t, TypeAccess, claims.Type)
+
+	claims, err = ExtractClaim(support)
+	require.NoError(t, err)
+	assert.Equal(t, "agent", claims.Act.Sub)
+
+	claims, err = ExtractRefreshClaim(refresh)
+	require.NoError(t, err)
+	assert.Equal(t, "u1", claims.UserId)
+
+	_, err = ExtractClaim(refresh)
+	assert.Error(t, err)
+	_, err = ExtractRefreshClaim(access)
+	assert.Error(t, err)
+	_, err = ExtractRefreshClaim(support)
+	assert.Error(t, err)
+}
+
+func TestTokenTypeSameKey(t *testing.T) {
+	t.Setenv("ACCESS_TOKEN", "secret")
+	t.Setenv("REFRESH_TOKEN", "secret")
+	user := &pb.LoginResponse{UserId: "u1"}
+
+	refresh, err := GenerateRefreshJWT(user)
+	require.NoError(t, err)
+	access, err := GenerateAccessJWT(user)
+	require.NoError(t, err)
+
+	_, err = ExtractClaim(refresh)
+	assert.Error(t, err)
+	_, err = ExtractRefreshClaim(access)
+	assert.Error(t, err)
+}
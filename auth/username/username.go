@@ -0,0 +1,34 @@
+package username
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrFormat   = errors.New("username must be 3-30 characters: letters, digits, '_' or '.', starting with a letter")
+	ErrReserved = errors.New("username is reserved")
+)
+
+var format = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.]{2,29}$`)
+
+// reserved tizim va xizmat sahifalari bilan adashtirilishi mumkin bo'lgan nomlar
+var reserved = []string{
+	"admin", "administrator", "root", "system", "support", "help", "staff",
+	"moderator", "owner", "security", "api", "auth", "login", "logout",
+	"register", "me", "settings", "account", "profile", "dinereserve",
+	"null", "undefined", "anonymous",
+}
+
+// Validate username formatini va band qilingan so'zlarni tekshiradi
+func Validate(name string) error {
+	if !format.MatchString(name) || strings.Contains(name, "..") || strings.HasSuffix(name, ".") {
+		return ErrFormat
+	}
+	if slices.Contains(reserved, strings.ToLower(name)) {
+		return ErrReserved
+	}
+	return nil
+}
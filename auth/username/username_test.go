@@ -0,0 +1,21 @@
+package username
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate("diyorbek.nematov"))
+	assert.NoError(t, Validate("Ali_99"))
+
+	assert.ErrorIs(t, Validate("ab"), ErrFormat)
+	assert.ErrorIs(t, Validate("9lives"), ErrFormat)
+	assert.ErrorIs(t, Validate("ali..vali"), ErrFormat)
+	assert.ErrorIs(t, Validate("ali."), ErrFormat)
+	assert.ErrorIs(t, Validate("ali@mail.com"), ErrFormat)
+
+	assert.ErrorIs(t, Validate("Admin"), ErrReserved)
+	assert.ErrorIs(t, Validate("support"), ErrReserved)
+}
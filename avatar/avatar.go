@@ -0,0 +1,140 @@
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"net/http"
+
+	_ "image/gif"
+	_ "image/png"
+)
+
+// Sizes har bir yuklangan rasm uchun tayyorlanadigan kvadrat o'lchamlar
+var Sizes = []int{64, 256, 512}
+
+// DefaultSize profildagi avatar_url shu o'lchamga ishora qiladi
+const DefaultSize = 256
+
+const (
+	maxPixels = 40_000_000
+	minSide   = 32
+	quality   = 85
+)
+
+var (
+	ErrUnsupportedType = errors.New("avatar must be a JPEG, PNG or GIF image")
+	ErrInvalidImage    = errors.New("avatar image could not be decoded")
+	ErrDimensions      = errors.New("avatar must be between 32x32 and 40 megapixels")
+)
+
+type Variant struct {
+	Size int
+	Data []byte
+}
+
+// Key variant faylining blob kaliti
+func Key(userId, version string, size int) string {
+	return fmt.Sprintf("%s_%d.jpg", Prefix(userId, version), size)
+}
+
+// Prefix bitta yuklashning barcha variantlari uchun umumiy kalit boshi.
+// version bo'sh bo'lsa foydalanuvchining barcha avatarlari.
+func Prefix(userId, version string) string {
+	if version == "" {
+		return "avatars/" + userId + "/"
+	}
+	return "avatars/" + userId + "/" + version
+}
+
+// Process rasmni tekshiradi, EXIF yo'nalishini qo'llaydi va markazdan
+// kvadrat qilib qirqilgan JPEG variantlarni qaytaradi. Qayta kodlash
+// natijasida EXIF va boshqa metadata tashlab yuboriladi.
+func Process(data []byte) ([]Variant, error) {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	// Dekodlashdan oldin tekshiriladi: kichik fayl ulkan rasm bo'lishi mumkin
+	if cfg.Width < minSide || cfg.Height < minSide || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrDimensions
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	src := flatten(img)
+	if contentType == "image/jpeg" {
+		src = orient(src, exifOrientation(data))
+	}
+	src = cropSquare(src)
+
+	variants := make([]Variant, 0, len(Sizes))
+	for _, size := range Sizes {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resize(src, size), &jpeg.Options{Quality: quality}); err != nil {
+			return nil, err
+		}
+		variants = append(variants, Variant{Size: size, Data: buf.Bytes()})
+	}
+
+	return variants, nil
+}
+
+// flatten rasmni oq fonga chizadi, JPEG da shaffoflik yo'q
+func flatten(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Over)
+	return dst
+}
+
+func cropSquare(img *image.RGBA) *image.RGBA {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	side := min(w, h)
+	x0, y0 := (w-side)/2, (h-side)/2
+	return img.SubImage(image.Rect(x0, y0, x0+side, y0+side)).(*image.RGBA)
+}
+
+// resize kvadrat rasmni size x size ga o'rtacha qiymat (box) filtri bilan
+// o'zgartiradi. Kattalashtirishda eng yaqin piksel olinadi.
+func resize(src *image.RGBA, size int) *image.RGBA {
+	b := src.Bounds()
+	side := b.Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	for y := 0; y < size; y++ {
+		sy0, sy1 := y*side/size, max((y+1)*side/size, y*side/size+1)
+		for x := 0; x < size; x++ {
+			sx0, sx1 := x*side/size, max((x+1)*side/size, x*side/size+1)
+
+			var r, g, bl, n uint32
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					c := src.RGBAAt(b.Min.X+sx, b.Min.Y+sy)
+					r += uint32(c.R)
+					g += uint32(c.G)
+					bl += uint32(c.B)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(bl / n), A: 255})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,103 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcess(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	variants, err := Process(buf.Bytes())
+	require.NoError(t, err)
+	require.Len(t, variants, len(Sizes))
+
+	for i, v := range variants {
+		assert.Equal(t, Sizes[i], v.Size)
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(v.Data))
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, v.Size, cfg.Width)
+		assert.Equal(t, v.Size, cfg.Height)
+	}
+}
+
+func TestProcessRejects(t *testing.T) {
+	_, err := Process([]byte("\x89PNG\r\n\x1a\nnot really a png"))
+	assert.ErrorIs(t, err, ErrInvalidImage)
+
+	_, err = Process([]byte("%PDF-1.4"))
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 10, 10))))
+	_, err = Process(buf.Bytes())
+	assert.ErrorIs(t, err, ErrDimensions)
+}
+
+func TestProcessStripsExif(t *testing.T) {
+	data := withOrientation(t, 6)
+	assert.Equal(t, 6, exifOrientation(data))
+
+	variants, err := Process(data)
+	require.NoError(t, err)
+	for _, v := range variants {
+		assert.NotContains(t, string(v.Data), "Exif")
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// 2x1: chapda qizil, o'ngda ko'k
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	red, blue := color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	src.SetRGBA(0, 0, red)
+	src.SetRGBA(1, 0, blue)
+
+	// 6: soat yo'nalishida 90 gradus, qizil tepada qoladi
+	dst := orient(src, 6)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), dst.Bounds())
+	assert.Equal(t, red, dst.RGBAAt(0, 0))
+	assert.Equal(t, blue, dst.RGBAAt(0, 1))
+
+	// 8: soat yo'nalishiga teskari, ko'k tepada
+	dst = orient(src, 8)
+	assert.Equal(t, blue, dst.RGBAAt(0, 0))
+	assert.Equal(t, red, dst.RGBAAt(0, 1))
+
+	dst = orient(src, 2)
+	assert.Equal(t, blue, dst.RGBAAt(0, 0))
+}
+
+// withOrientation Orientation tegi bor APP1 segmentli JPEG yasaydi
+func withOrientation(t *testing.T, orientation uint16) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 64, 48)), nil))
+	data := buf.Bytes()
+
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
@@ -0,0 +1,30 @@
+// audit-verify audit jurnalidagi hash zanjirini tekshiradi. Zanjir buzilgan
+// bo'lsa 1 kodi bilan chiqadi, shuning uchun cron yoki CI da ishlatish mumkin.
+package main
+
+import (
+	"auth-service/storage/postgres"
+	"fmt"
+	"log"
+	"os"
+)
+
+func main() {
+	db, err := postgres.ConnectDB()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	resp, err := postgres.NewAuditRepo(db).Verify()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !resp.Valid {
+		fmt.Printf("audit log is tampered: entry %d does not match the chain (%d entries checked)\n", resp.BrokenId, resp.Checked)
+		os.Exit(1)
+	}
+
+	fmt.Printf("audit log is valid: %d entries checked\n", resp.Checked)
+}
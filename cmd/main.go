@@ -4,11 +4,17 @@ import (
 	"auth-service/api"
 	"auth-service/api/handler"
 	"auth-service/config"
+	"auth-service/jobs"
 	"auth-service/logs"
+	"auth-service/notify"
+	"auth-service/ratelimit"
 	"auth-service/server"
+	"auth-service/storage/blob"
 	"auth-service/storage/postgres"
+	"context"
 	"log"
 	"sync"
+	"time"
 )
 
 func main() {
@@ -22,14 +28,48 @@ func main() {
 	defer db.Close()
 
 	cfg := config.Load()
-	router := api.Routes(handler.NewHandler(db, logs.Logger))
+
+	var store ratelimit.Store = ratelimit.NewMemoryStore()
+	if cfg.RATE_LIMIT_STORE == "postgres" {
+		store = postgres.NewRateLimitRepo(db)
+	}
+	overrides, err := ratelimit.ParseOverrides(cfg.RATE_LIMITS)
+	if err != nil {
+		logs.Logger.Error("Invalid RATE_LIMITS", "error", err.Error())
+		log.Fatal(err)
+	}
+	limiter := ratelimit.NewLimiter(store).WithOverrides(overrides)
+
+	router := api.Routes(handler.NewHandler(db, logs.Logger), limiter)
+
+	purger := &jobs.AccountPurger{
+		Users:    postgres.NewUserRepo(db),
+		Audit:    postgres.NewAuditRepo(db),
+		Blobs:    blob.New(cfg),
+		Logger:   logs.Logger,
+		Interval: time.Hour,
+	}
+	go purger.Run(context.Background())
+
+	exporter := &jobs.ExportWorker{
+		Exports:     postgres.NewExportRepo(db),
+		Users:       postgres.NewUserRepo(db),
+		Sessions:    postgres.NewSessionRepo(db),
+		LoginEvents: postgres.NewLoginEventRepo(db),
+		Consents:    postgres.NewConsentRepo(db),
+		Notifier:    notify.New(cfg),
+		Config:      cfg,
+		Logger:      logs.Logger,
+		Interval:    30 * time.Second,
+	}
+	go exporter.Run(context.Background())
 
 	var wg sync.WaitGroup
 	wg.Add(2)
 
 	go func() {
 		defer wg.Done()
-		server.ServerRun(db)
+		server.ServerRun(db, limiter)
 	}()
 
 	go func() {
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
@@ -18,6 +19,35 @@ type Config struct {
 	DB_PASSWORD   string
 	ACCESS_TOKEN  string
 	REFRESH_TOKEN string
+
+	RATE_LIMIT_STORE string
+	RATE_LIMITS      string
+	TRUSTED_PROXIES  []string
+
+	APP_URL            string
+	SMTP_HOST          string
+	SMTP_PORT          int
+	SMTP_USER          string
+	SMTP_PASSWORD      string
+	SMTP_FROM          string
+	SMS_WEBHOOK_URL    string
+	STEP_UP_NEW_DEVICE bool
+
+	DELETION_GRACE_DAYS int
+
+	API_URL          string
+	EXPORT_DIR       string
+	EXPORT_TTL_HOURS int
+	// EXPORT_LEASE_MINUTES dan uzoq processing da qolgan eksport qayta olinadi
+	EXPORT_LEASE_MINUTES int
+	LINK_SIGNING_KEY     string
+
+	USERNAME_COOLDOWN_DAYS int
+
+	BLOB_DIR         string
+	AVATAR_MAX_BYTES int64
+
+	RESERVATION_SERVICE_ADDR string
 }
 
 func coalesce(env string, defaultValue interface{}) interface{} {
@@ -28,6 +58,17 @@ func coalesce(env string, defaultValue interface{}) interface{} {
 	return value
 }
 
+// splitList vergul bilan ajratilgan qiymatlar, bo'shlari tashlab yuboriladi
+func splitList(value string) []string {
+	list := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
 func Load() Config {
 	if err := godotenv.Load(); err != nil {
 		log.Println("No .env file found")
@@ -45,5 +86,33 @@ func Load() Config {
 	cfg.ACCESS_TOKEN = cast.ToString(coalesce("ACCESS_TOKEN", "my_secret_key"))
 	cfg.REFRESH_TOKEN = cast.ToString(coalesce("REFRESH_TOKEN", "my_secret_key"))
 
+	cfg.RATE_LIMIT_STORE = cast.ToString(coalesce("RATE_LIMIT_STORE", "memory"))
+	cfg.RATE_LIMITS = cast.ToString(coalesce("RATE_LIMITS", ""))
+	cfg.TRUSTED_PROXIES = splitList(cast.ToString(coalesce("TRUSTED_PROXIES", "")))
+
+	cfg.APP_URL = cast.ToString(coalesce("APP_URL", "http://localhost:3000"))
+	cfg.SMTP_HOST = cast.ToString(coalesce("SMTP_HOST", ""))
+	cfg.SMTP_PORT = cast.ToInt(coalesce("SMTP_PORT", 587))
+	cfg.SMTP_USER = cast.ToString(coalesce("SMTP_USER", ""))
+	cfg.SMTP_PASSWORD = cast.ToString(coalesce("SMTP_PASSWORD", ""))
+	cfg.SMTP_FROM = cast.ToString(coalesce("SMTP_FROM", "no-reply@dinereserve.uz"))
+	cfg.SMS_WEBHOOK_URL = cast.ToString(coalesce("SMS_WEBHOOK_URL", ""))
+	cfg.STEP_UP_NEW_DEVICE = cast.ToBool(coalesce("STEP_UP_NEW_DEVICE", false))
+
+	cfg.DELETION_GRACE_DAYS = cast.ToInt(coalesce("DELETION_GRACE_DAYS", 30))
+
+	cfg.API_URL = cast.ToString(coalesce("API_URL", "http://localhost:8081"))
+	cfg.EXPORT_DIR = cast.ToString(coalesce("EXPORT_DIR", "./exports"))
+	cfg.EXPORT_TTL_HOURS = cast.ToInt(coalesce("EXPORT_TTL_HOURS", 72))
+	cfg.EXPORT_LEASE_MINUTES = cast.ToInt(coalesce("EXPORT_LEASE_MINUTES", 15))
+	cfg.LINK_SIGNING_KEY = cast.ToString(coalesce("LINK_SIGNING_KEY", "my_secret_key"))
+
+	cfg.USERNAME_COOLDOWN_DAYS = cast.ToInt(coalesce("USERNAME_COOLDOWN_DAYS", 90))
+
+	cfg.BLOB_DIR = cast.ToString(coalesce("BLOB_DIR", "./blobs"))
+	cfg.AVATAR_MAX_BYTES = cast.ToInt64(coalesce("AVATAR_MAX_BYTES", 5<<20))
+
+	cfg.RESERVATION_SERVICE_ADDR = cast.ToString(coalesce("RESERVATION_SERVICE_ADDR", "localhost:50051"))
+
 	return cfg
 }
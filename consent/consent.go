@@ -0,0 +1,47 @@
+package consent
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+)
+
+// Huquqiy hujjat turlari. Har bir turning oxirgi e'lon qilingan versiyasi
+// amalda va uni qabul qilish majburiy.
+const (
+	TypeTerms   = "terms_of_service"
+	TypePrivacy = "privacy_policy"
+)
+
+var Types = []string{TypeTerms, TypePrivacy}
+
+// Required login va ro'yxatdan o'tishda qaytariladigan kod
+const Required = "consent_required"
+
+var versionPattern = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z.\-]{0,19}$`)
+
+func ValidType(t string) bool {
+	return slices.Contains(Types, t)
+}
+
+// ValidVersion masalan "2024-06" yoki "3.1"
+func ValidVersion(v string) bool {
+	return versionPattern.MatchString(v)
+}
+
+// ValidURL hujjat matni joylashgan https manzil
+func ValidURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
+
+// Missing accepted da bo'lmagan required id larini qaytaradi
+func Missing(required, accepted []string) []string {
+	missing := []string{}
+	for _, id := range required {
+		if !slices.Contains(accepted, id) {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,28 @@
+package consent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidVersion(t *testing.T) {
+	for _, v := range []string{"1", "2024-06", "3.1.0", "v2"} {
+		assert.True(t, ValidVersion(v), v)
+	}
+	for _, v := range []string{"", ".1", "1 0", "12345678901234567890x"} {
+		assert.False(t, ValidVersion(v), v)
+	}
+}
+
+func TestValidURL(t *testing.T) {
+	assert.True(t, ValidURL("https://dinereserve.uz/legal/terms-2024-06"))
+	assert.False(t, ValidURL("http://dinereserve.uz/legal/terms"))
+	assert.False(t, ValidURL("/legal/terms"))
+}
+
+func TestMissing(t *testing.T) {
+	assert.Equal(t, []string{"b"}, Missing([]string{"a", "b"}, []string{"a", "c"}))
+	assert.Empty(t, Missing([]string{"a"}, []string{"a"}))
+	assert.Empty(t, Missing(nil, nil))
+}
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key VARCHAR(255) PRIMARY KEY,
+    tokens DOUBLE PRECISION NOT NULL DEFAULT 0,
+    window_start TIMESTAMPTZ,
+    count BIGINT NOT NULL DEFAULT 0,
+    prev_count BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS rate_limits_expires_at_idx ON rate_limits (expires_at);
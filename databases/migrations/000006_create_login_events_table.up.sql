@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS login_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID REFERENCES users(id),
+    identifier VARCHAR(100),
+    success BOOLEAN NOT NULL,
+    ip VARCHAR(45),
+    user_agent VARCHAR(255),
+    method VARCHAR(20) NOT NULL,
+    failure_reason VARCHAR(50),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS login_events_user_id_idx ON login_events (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS login_events_ip_idx ON login_events (ip, created_at DESC);
+
+-- Jadval faqat qo'shish uchun: yozuvlarni o'zgartirish va o'chirish taqiqlanadi
+CREATE OR REPLACE FUNCTION login_events_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'login_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER login_events_append_only
+    BEFORE UPDATE OR DELETE ON login_events
+    FOR EACH ROW EXECUTE FUNCTION login_events_append_only();
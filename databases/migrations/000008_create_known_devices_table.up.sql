@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS known_devices (
+    user_id UUID NOT NULL REFERENCES users(id),
+    fingerprint CHAR(64) NOT NULL,
+    ip_range VARCHAR(50) NOT NULL,
+    device_name VARCHAR(100),
+    first_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, fingerprint, ip_range)
+);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS user_profiles_phone_number_idx;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS phone_verified;
+DROP INDEX IF EXISTS user_profiles_username_lower_idx;
+DROP INDEX IF EXISTS users_username_lower_idx;
+DROP INDEX IF EXISTS users_email_lower_idx;
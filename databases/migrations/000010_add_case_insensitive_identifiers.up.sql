@@ -0,0 +1,44 @@
+-- Katta-kichik harf farqi bilan takrorlangan email va usernamelarni topadi.
+-- To'qnashuvlar bo'lsa migratsiya ro'yxat bilan to'xtaydi: ularni qo'lda
+-- hal qilib, migratsiyani qayta ishga tushiring.
+DO $$
+DECLARE
+    collision RECORD;
+    total INT := 0;
+BEGIN
+    FOR collision IN
+        SELECT 'email' AS field, LOWER(TRIM(email)) AS value, STRING_AGG(id::TEXT, ', ' ORDER BY created_at) AS ids
+        FROM users
+        WHERE email IS NOT NULL
+        GROUP BY LOWER(TRIM(email))
+        HAVING COUNT(*) > 1
+        UNION ALL
+        SELECT 'username', LOWER(username), STRING_AGG(id::TEXT, ', ' ORDER BY created_at)
+        FROM users
+        WHERE username IS NOT NULL
+        GROUP BY LOWER(username)
+        HAVING COUNT(*) > 1
+        UNION ALL
+        SELECT 'profile username', LOWER(username), STRING_AGG(user_id::TEXT, ', ' ORDER BY created_at)
+        FROM user_profiles
+        WHERE username IS NOT NULL
+        GROUP BY LOWER(username)
+        HAVING COUNT(*) > 1
+    LOOP
+        RAISE NOTICE 'collision on % "%": users %', collision.field, collision.value, collision.ids;
+        total := total + 1;
+    END LOOP;
+
+    IF total > 0 THEN
+        RAISE EXCEPTION '% case-insensitive identifier collisions found, resolve them before migrating', total;
+    END IF;
+END $$;
+
+UPDATE users SET email = LOWER(TRIM(email)) WHERE email <> LOWER(TRIM(email));
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_lower_idx ON users (LOWER(email));
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_lower_idx ON users (LOWER(username));
+CREATE UNIQUE INDEX IF NOT EXISTS user_profiles_username_lower_idx ON user_profiles (LOWER(username));
+
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS phone_verified BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS user_profiles_phone_number_idx ON user_profiles (phone_number) WHERE phone_verified;
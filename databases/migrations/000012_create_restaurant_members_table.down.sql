@@ -0,0 +1 @@
+DROP TABLE IF EXISTS restaurant_members;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS restaurant_members (
+    restaurant_id UUID NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id),
+    role VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (restaurant_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS restaurant_members_user_id_idx ON restaurant_members (user_id);
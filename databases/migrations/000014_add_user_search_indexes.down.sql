@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS user_profiles_user_id_idx;
+DROP INDEX IF EXISTS users_created_at_id_idx;
+DROP INDEX IF EXISTS user_profiles_phone_number_trgm_idx;
+DROP INDEX IF EXISTS user_profiles_fullname_trgm_idx;
+DROP INDEX IF EXISTS users_username_trgm_idx;
+DROP INDEX IF EXISTS users_email_trgm_idx;
+
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified;
@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- ILIKE '%...%' va similarity qidiruvlari uchun
+CREATE INDEX IF NOT EXISTS users_email_trgm_idx ON users USING GIN (email gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS users_username_trgm_idx ON users USING GIN (username gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS user_profiles_fullname_trgm_idx ON user_profiles USING GIN (fullname gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS user_profiles_phone_number_trgm_idx ON user_profiles USING GIN (phone_number gin_trgm_ops);
+
+-- Cursor pagination uchun
+CREATE INDEX IF NOT EXISTS users_created_at_id_idx ON users (created_at, id);
+CREATE INDEX IF NOT EXISTS user_profiles_user_id_idx ON user_profiles (user_id);
@@ -0,0 +1,15 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status VARCHAR(32) NOT NULL DEFAULT 'active'
+    CHECK (status IN ('pending_verification', 'active', 'suspended', 'deactivated', 'deleted'));
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_reason TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ;
+-- NULL bo'lsa to'xtatish muddatsiz
+ALTER TABLE users ADD COLUMN IF NOT EXISTS suspended_until TIMESTAMPTZ;
+
+UPDATE users
+SET
+    status = 'deleted',
+    status_changed_at = TO_TIMESTAMP(deleted_at)
+WHERE
+    deleted_at <> 0;
+
+CREATE INDEX IF NOT EXISTS users_status_idx ON users (status);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS users_deletion_scheduled_at_idx;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
+
+UPDATE users SET status = 'active' WHERE status = 'pending_deletion';
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('pending_verification', 'active', 'suspended', 'deactivated', 'deleted'));
@@ -0,0 +1,8 @@
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_status_check;
+ALTER TABLE users ADD CONSTRAINT users_status_check
+    CHECK (status IN ('pending_verification', 'active', 'suspended', 'deactivated', 'pending_deletion', 'deleted'));
+
+-- Shu vaqtdan keyin purge jobi foydalanuvchini anonimlashtiradi
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS users_deletion_scheduled_at_idx ON users (deletion_scheduled_at) WHERE status = 'pending_deletion';
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS data_exports (
+    id UUID DEFAULT GEN_RANDOM_UUID() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'ready', 'failed', 'expired')),
+    file_path TEXT,
+    error TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS data_exports_user_id_idx ON data_exports (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS data_exports_pending_idx ON data_exports (created_at) WHERE status = 'pending';
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS email_changes (
+    id UUID DEFAULT GEN_RANDOM_UUID() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    old_email VARCHAR(100) NOT NULL,
+    new_email VARCHAR(100) NOT NULL,
+    confirm_token_hash CHAR(64) NOT NULL UNIQUE,
+    undo_token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    -- Eski manzilga yuborilgan bekor qilish havolasi tasdiqlangandan keyin ham ishlaydi
+    undo_expires_at TIMESTAMPTZ NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS email_changes_user_id_idx ON email_changes (user_id) WHERE confirmed_at IS NULL AND cancelled_at IS NULL;
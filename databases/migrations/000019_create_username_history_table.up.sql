@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS username_history (
+    id UUID DEFAULT GEN_RANDOM_UUID() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    old_username VARCHAR(100) NOT NULL,
+    new_username VARCHAR(100) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS username_history_old_username_idx ON username_history (LOWER(old_username), changed_at DESC);
+CREATE INDEX IF NOT EXISTS username_history_user_id_idx ON username_history (user_id, changed_at DESC);
+
+-- Ikki jadval bir-biridan ajralib qolgan bo'lsa users dagi qiymat asosiy.
+-- Avval NULL qilinadi, aks holda almashgan nomlar unique indeksga uriladi.
+CREATE TEMP TABLE drifted_profiles AS
+    SELECT p.user_id, u.username FROM user_profiles p JOIN users u ON u.id = p.user_id WHERE p.username IS DISTINCT FROM u.username;
+UPDATE user_profiles p SET username = NULL FROM drifted_profiles d WHERE p.user_id = d.user_id;
+UPDATE user_profiles p SET username = d.username FROM drifted_profiles d WHERE p.user_id = d.user_id;
+DROP TABLE drifted_profiles;
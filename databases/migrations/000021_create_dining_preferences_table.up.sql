@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS dining_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+    dietary_restrictions TEXT[] NOT NULL DEFAULT '{}',
+    allergies TEXT[] NOT NULL DEFAULT '{}',
+    seating VARCHAR(30),
+    favorite_cuisines TEXT[] NOT NULL DEFAULT '{}',
+    occasions JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
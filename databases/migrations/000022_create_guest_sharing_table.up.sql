@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS guest_sharing (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+    share_name BOOLEAN NOT NULL DEFAULT FALSE,
+    share_phone BOOLEAN NOT NULL DEFAULT FALSE,
+    share_allergies BOOLEAN NOT NULL DEFAULT FALSE,
+    share_visit_history BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1 @@
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
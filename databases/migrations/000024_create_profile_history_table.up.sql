@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS profile_history (
+    id UUID DEFAULT GEN_RANDOM_UUID() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    version BIGINT NOT NULL,
+    username VARCHAR(100),
+    fullname VARCHAR(200),
+    date_of_birth DATE,
+    phone_number VARCHAR(20),
+    address VARCHAR(255),
+    avatar_url VARCHAR(512),
+    changed_fields TEXT[] NOT NULL DEFAULT '{}',
+    changed_by VARCHAR(100),
+    channel VARCHAR(20) NOT NULL,
+    reverted_from BIGINT,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS profile_history_user_id_changed_at_idx ON profile_history (user_id, changed_at DESC);
+
+-- Mavjud profillar uchun boshlang'ich versiya
+INSERT INTO profile_history (user_id, version, username, fullname, date_of_birth, phone_number, address, avatar_url, channel, changed_at)
+    SELECT user_id, version, username, fullname, date_of_birth, phone_number, address, avatar_url, 'migration', COALESCE(updated_at, CURRENT_TIMESTAMP)
+    FROM user_profiles
+ON CONFLICT (user_id, version) DO NOTHING;
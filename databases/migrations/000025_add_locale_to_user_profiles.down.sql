@@ -0,0 +1,4 @@
+ALTER TABLE profile_history DROP COLUMN IF EXISTS timezone;
+ALTER TABLE profile_history DROP COLUMN IF EXISTS locale;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS timezone;
+ALTER TABLE user_profiles DROP COLUMN IF EXISTS locale;
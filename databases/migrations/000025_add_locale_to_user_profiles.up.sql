@@ -0,0 +1,4 @@
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS locale VARCHAR(10);
+ALTER TABLE user_profiles ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
+ALTER TABLE profile_history ADD COLUMN IF NOT EXISTS locale VARCHAR(10);
+ALTER TABLE profile_history ADD COLUMN IF NOT EXISTS timezone VARCHAR(64);
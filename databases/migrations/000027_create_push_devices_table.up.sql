@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS push_devices (
+    id UUID DEFAULT GEN_RANDOM_UUID() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    session_id UUID NOT NULL REFERENCES sessions(id),
+    provider VARCHAR(10) NOT NULL,
+    token VARCHAR(4096) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS push_devices_user_id_idx ON push_devices (user_id);
+CREATE INDEX IF NOT EXISTS push_devices_session_id_idx ON push_devices (session_id);
@@ -0,0 +1,3 @@
+ALTER TABLE login_challenges DROP COLUMN IF EXISTS purpose;
+DROP TABLE IF EXISTS consents;
+DROP TABLE IF EXISTS legal_documents;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS legal_documents (
+    id UUID DEFAULT GEN_RANDOM_UUID() PRIMARY KEY,
+    type VARCHAR(30) NOT NULL,
+    version VARCHAR(20) NOT NULL,
+    url TEXT NOT NULL,
+    published_by UUID REFERENCES users(id),
+    published_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (type, version)
+);
+
+CREATE TABLE IF NOT EXISTS consents (
+    user_id UUID NOT NULL REFERENCES users(id),
+    document_id UUID NOT NULL REFERENCES legal_documents(id),
+    accepted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ip VARCHAR(45),
+    user_agent VARCHAR(255),
+    PRIMARY KEY (user_id, document_id)
+);
+
+CREATE INDEX IF NOT EXISTS consents_document_id_idx ON consents (document_id);
+
+ALTER TABLE login_challenges ADD COLUMN IF NOT EXISTS purpose VARCHAR(20) NOT NULL DEFAULT 'step_up';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS user_profiles_phone_number_idx;
+CREATE INDEX IF NOT EXISTS user_profiles_phone_number_idx ON user_profiles (phone_number) WHERE phone_verified;
+
+DROP TABLE IF EXISTS phone_verifications;
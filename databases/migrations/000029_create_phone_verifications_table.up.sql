@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS phone_verifications (
+    id UUID DEFAULT GEN_RANDOM_UUID() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    -- Kod shu raqamga yuborilgan; tasdiqlashda profil raqami bilan solishtiriladi
+    phone_number VARCHAR(20) NOT NULL,
+    code_hash CHAR(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS phone_verifications_user_id_idx ON phone_verifications (user_id) WHERE used_at IS NULL;
+
+-- Telefon bilan login bitta egani topishi uchun tasdiqlangan raqam yagona
+DROP INDEX IF EXISTS user_profiles_phone_number_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS user_profiles_phone_number_idx ON user_profiles (phone_number) WHERE phone_verified;
@@ -0,0 +1,28 @@
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS redacted_at TIMESTAMPTZ;
+
+-- Jadvallar faqat qo'shish uchun qoladi. Yagona istisno: purge tranzaksiyasi
+-- (SET LOCAL auth.purge_redact = 'on') shaxsiy maydonlarni o'chira oladi,
+-- qolgan ustunlar o'zgarmasligi kerak.
+CREATE OR REPLACE FUNCTION login_events_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND current_setting('auth.purge_redact', TRUE) = 'on'
+        AND NEW.identifier IS NULL AND NEW.ip IS NULL AND NEW.user_agent IS NULL
+        AND to_jsonb(NEW) - ARRAY['identifier', 'ip', 'user_agent'] = to_jsonb(OLD) - ARRAY['identifier', 'ip', 'user_agent'] THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'login_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND current_setting('auth.purge_redact', TRUE) = 'on'
+        AND OLD.redacted_at IS NULL AND NEW.redacted_at IS NOT NULL
+        AND to_jsonb(NEW) - ARRAY['before', 'after', 'redacted_at'] = to_jsonb(OLD) - ARRAY['before', 'after', 'redacted_at'] THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,13 @@
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND current_setting('auth.purge_redact', TRUE) = 'on'
+        AND OLD.redacted_at IS NULL AND NEW.redacted_at IS NOT NULL
+        AND to_jsonb(NEW) - ARRAY['before', 'after', 'redacted_at'] = to_jsonb(OLD) - ARRAY['before', 'after', 'redacted_at'] THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE audit_logs DROP COLUMN IF EXISTS payload_hash;
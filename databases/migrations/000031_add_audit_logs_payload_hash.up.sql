@@ -0,0 +1,17 @@
+-- Yangi yozuvlar hashi before/after o'rniga ularning digestidan hisoblanadi
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS payload_hash CHAR(64);
+
+-- Redaction faqat payload_hash li yozuvlarda ruxsat etiladi: ularning hashi
+-- before/after o'zgargandan keyin ham qayta hisoblanadi
+CREATE OR REPLACE FUNCTION audit_logs_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND current_setting('auth.purge_redact', TRUE) = 'on'
+        AND OLD.payload_hash IS NOT NULL
+        AND OLD.redacted_at IS NULL AND NEW.redacted_at IS NOT NULL
+        AND to_jsonb(NEW) - ARRAY['before', 'after', 'redacted_at'] = to_jsonb(OLD) - ARRAY['before', 'after', 'redacted_at'] THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_logs is append-only';
+END;
+$$ LANGUAGE plpgsql;
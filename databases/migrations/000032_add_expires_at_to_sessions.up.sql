@@ -0,0 +1,2 @@
+-- Support sessiyalari token muddati bilan birga tugaydi
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS expires_at TIMESTAMP;
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS email_verifications (
+    id UUID DEFAULT GEN_RANDOM_UUID() PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    -- Havola shu manzilga yuborilgan; tasdiqlashda users.email bilan solishtiriladi
+    email VARCHAR(100) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS email_verifications_user_id_idx ON email_verifications (user_id) WHERE used_at IS NULL;
+
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS data_exports_processing_idx;
+
+ALTER TABLE data_exports DROP COLUMN IF EXISTS claimed_at;
@@ -0,0 +1,4 @@
+-- Worker vazifani olgan vaqt; lease tugagan processing eksportlar qayta olinadi
+ALTER TABLE data_exports ADD COLUMN IF NOT EXISTS claimed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS data_exports_processing_idx ON data_exports (claimed_at) WHERE status = 'processing';
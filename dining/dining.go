@@ -0,0 +1,100 @@
+package dining
+
+import (
+	pb "auth-service/generated/auth_service"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Lug'atlar. Yangi qiymat qo'shish mumkin, o'chirish esa saqlangan
+// profillarni yaroqsiz qiladi.
+var (
+	Dietary = []string{
+		"vegetarian", "vegan", "pescatarian", "halal", "kosher", "gluten_free",
+		"lactose_free", "keto", "low_sodium", "diabetic",
+	}
+	Allergies = []string{
+		"peanuts", "tree_nuts", "milk", "eggs", "fish", "shellfish", "molluscs",
+		"soy", "wheat", "sesame", "mustard", "celery", "lupin", "sulphites",
+	}
+	Seating = []string{
+		"no_preference", "indoor", "outdoor", "window", "bar", "booth", "quiet_area", "accessible",
+	}
+	Cuisines = []string{
+		"uzbek", "italian", "japanese", "chinese", "korean", "turkish", "georgian",
+		"indian", "french", "american", "mexican", "middle_eastern", "thai",
+		"vietnamese", "mediterranean", "seafood", "steakhouse", "vegetarian",
+	}
+	Occasions = []string{"birthday", "anniversary", "other"}
+)
+
+const (
+	maxOccasions = 10
+	maxNoteLen   = 100
+)
+
+// Normalize qiymatlarni kichik harfga o'tkazadi, takrorlarni olib tashlaydi
+// va lug'atdan tekshiradi
+func Normalize(p *pb.DiningPreferences) error {
+	var err error
+	if p.DietaryRestrictions, err = normalizeList("dietary_restrictions", p.DietaryRestrictions, Dietary); err != nil {
+		return err
+	}
+	if p.Allergies, err = normalizeList("allergies", p.Allergies, Allergies); err != nil {
+		return err
+	}
+	if p.FavoriteCuisines, err = normalizeList("favorite_cuisines", p.FavoriteCuisines, Cuisines); err != nil {
+		return err
+	}
+
+	p.Seating = strings.ToLower(strings.TrimSpace(p.Seating))
+	if p.Seating != "" && !slices.Contains(Seating, p.Seating) {
+		return fmt.Errorf("seating: unknown value %q", p.Seating)
+	}
+
+	if len(p.Occasions) > maxOccasions {
+		return fmt.Errorf("occasions: at most %d allowed", maxOccasions)
+	}
+	for _, o := range p.Occasions {
+		o.Type = strings.ToLower(strings.TrimSpace(o.Type))
+		if !slices.Contains(Occasions, o.Type) {
+			return fmt.Errorf("occasions: unknown type %q", o.Type)
+		}
+		if !validDate(o.Date) {
+			return fmt.Errorf("occasions: date %q must be MM-DD or YYYY-MM-DD", o.Date)
+		}
+		o.Note = strings.TrimSpace(o.Note)
+		if utf8.RuneCountInString(o.Note) > maxNoteLen {
+			return fmt.Errorf("occasions: note must be at most %d characters", maxNoteLen)
+		}
+	}
+
+	return nil
+}
+
+func normalizeList(field string, values, vocabulary []string) ([]string, error) {
+	out := []string{}
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if !slices.Contains(vocabulary, v) {
+			return nil, fmt.Errorf("%s: unknown value %q", field, v)
+		}
+		if !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+	return out, nil
+}
+
+// validDate yil ixtiyoriy: tug'ilgan kunda ko'pincha faqat oy va kun ma'lum
+func validDate(date string) bool {
+	if _, err := time.Parse("2006-01-02", date); err == nil {
+		return true
+	}
+	// 29-fevral kabi sanalar uchun kabisa yili bilan tekshiriladi
+	_, err := time.Parse("2006-01-02", "2000-"+date)
+	return err == nil && len(date) == 5
+}
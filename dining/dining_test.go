@@ -0,0 +1,41 @@
+package dining
+
+import (
+	pb "auth-service/generated/auth_service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	p := &pb.DiningPreferences{
+		DietaryRestrictions: []string{" Halal", "halal"},
+		Allergies:           []string{"peanuts"},
+		Seating:             "Window",
+		FavoriteCuisines:    []string{"uzbek", "georgian"},
+		Occasions: []*pb.Occasion{
+			{Type: "Birthday", Date: "02-29"},
+			{Type: "anniversary", Date: "2019-06-15", Note: " wedding "},
+		},
+	}
+	require.NoError(t, Normalize(p))
+	assert.Equal(t, []string{"halal"}, p.DietaryRestrictions)
+	assert.Equal(t, "window", p.Seating)
+	assert.Equal(t, "birthday", p.Occasions[0].Type)
+	assert.Equal(t, "wedding", p.Occasions[1].Note)
+}
+
+func TestNormalizeRejects(t *testing.T) {
+	for _, p := range []*pb.DiningPreferences{
+		{DietaryRestrictions: []string{"carnivore"}},
+		{Allergies: []string{"cats"}},
+		{Seating: "roof"},
+		{FavoriteCuisines: []string{"martian"}},
+		{Occasions: []*pb.Occasion{{Type: "graduation", Date: "06-01"}}},
+		{Occasions: []*pb.Occasion{{Type: "birthday", Date: "13-01"}}},
+		{Occasions: []*pb.Occasion{{Type: "birthday", Date: "2-1"}}},
+	} {
+		assert.Error(t, Normalize(p))
+	}
+}
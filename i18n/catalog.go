@@ -0,0 +1,275 @@
+package i18n
+
+// catalog inglizcha xabar -> til -> tarjima. Ingliz tili kalitning o'zi.
+var catalog = map[string]map[string]string{
+	"User not found":     {LocaleUz: "Foydalanuvchi topilmadi", LocaleRu: "Пользователь не найден"},
+	"Not found":          {LocaleUz: "Topilmadi", LocaleRu: "Не найдено"},
+	"Too many requests":  {LocaleUz: "So'rovlar juda ko'p", LocaleRu: "Слишком много запросов"},
+	"Reason is required": {LocaleUz: "Sababni ko'rsating", LocaleRu: "Укажите причину"},
+	"created_from and created_to must be RFC3339 timestamps":             {LocaleUz: "created_from va created_to RFC3339 formatida bo'lishi kerak", LocaleRu: "created_from и created_to должны быть в формате RFC3339"},
+	"from and to must be RFC3339 timestamps":                             {LocaleUz: "from va to RFC3339 formatida bo'lishi kerak", LocaleRu: "from и to должны быть в формате RFC3339"},
+	"at must be an RFC3339 timestamp":                                    {LocaleUz: "at RFC3339 formatida bo'lishi kerak", LocaleRu: "at должен быть в формате RFC3339"},
+	"Failed to process request":                                          {LocaleUz: "So'rovni bajarib bo'lmadi", LocaleRu: "Не удалось обработать запрос"},
+	"Authorization header is required":                                   {LocaleUz: "Authorization sarlavhasi talab qilinadi", LocaleRu: "Требуется заголовок Authorization"},
+	"Invalid token":                                                      {LocaleUz: "Token noto'g'ri", LocaleRu: "Недействительный токен"},
+	"Token has expired":                                                  {LocaleUz: "Token muddati tugagan", LocaleRu: "Срок действия токена истёк"},
+	"Refresh token is required":                                          {LocaleUz: "Refresh token talab qilinadi", LocaleRu: "Требуется refresh-токен"},
+	"Impersonation tokens cannot be refreshed":                           {LocaleUz: "Support tokenlarini yangilab bo'lmaydi", LocaleRu: "Токены поддержки нельзя обновить"},
+	"Failed to check session":                                            {LocaleUz: "Sessiyani tekshirib bo'lmadi", LocaleRu: "Не удалось проверить сессию"},
+	"Session has been revoked":                                           {LocaleUz: "Sessiya yopilgan", LocaleRu: "Сессия отозвана"},
+	"Failed to generate access token":                                    {LocaleUz: "Access token yaratib bo'lmadi", LocaleRu: "Не удалось создать access-токен"},
+	"Failed to generate refresh token":                                   {LocaleUz: "Refresh token yaratib bo'lmadi", LocaleRu: "Не удалось создать refresh-токен"},
+	"Failed to generate new access token":                                {LocaleUz: "Yangi access token yaratib bo'lmadi", LocaleRu: "Не удалось создать новый access-токен"},
+	"Failed to create session":                                           {LocaleUz: "Sessiya yaratib bo'lmadi", LocaleRu: "Не удалось создать сессию"},
+	"Session not found":                                                  {LocaleUz: "Sessiya topilmadi", LocaleRu: "Сессия не найдена"},
+	"Session revoked successfully":                                       {LocaleUz: "Sessiya yopildi", LocaleRu: "Сессия завершена"},
+	"Other sessions revoked successfully":                                {LocaleUz: "Boshqa sessiyalar yopildi", LocaleRu: "Остальные сессии завершены"},
+	"Sessions revoked successfully":                                      {LocaleUz: "Sessiyalar yopildi", LocaleRu: "Сессии завершены"},
+	"Logged out successfully":                                            {LocaleUz: "Tizimdan chiqildi", LocaleRu: "Вы вышли из системы"},
+	"Admin access required":                                              {LocaleUz: "Administrator huquqi kerak", LocaleRu: "Требуются права администратора"},
+	"This action is not allowed while impersonating a user":              {LocaleUz: "Foydalanuvchi nomidan ishlayotganda bu amal taqiqlangan", LocaleRu: "Это действие запрещено в режиме входа от имени пользователя"},
+	"this action is not allowed while impersonating a user":              {LocaleUz: "foydalanuvchi nomidan ishlayotganda bu amal taqiqlangan", LocaleRu: "это действие запрещено в режиме входа от имени пользователя"},
+	"Email is already registered":                                        {LocaleUz: "Bu email allaqachon ro'yxatdan o'tgan", LocaleRu: "Этот email уже зарегистрирован"},
+	"Username is already taken":                                          {LocaleUz: "Bu username band", LocaleRu: "Это имя пользователя уже занято"},
+	"Username must not be an email or phone number":                      {LocaleUz: "Username email yoki telefon raqami bo'lmasligi kerak", LocaleRu: "Имя пользователя не может быть email или номером телефона"},
+	"Invalid password":                                                   {LocaleUz: "Parol noto'g'ri", LocaleRu: "Неверный пароль"},
+	"Password must be at least 8 characters":                             {LocaleUz: "Parol kamida 8 belgidan iborat bo'lishi kerak", LocaleRu: "Пароль должен содержать не менее 8 символов"},
+	"Failed to update password":                                          {LocaleUz: "Parolni yangilab bo'lmadi", LocaleRu: "Не удалось обновить пароль"},
+	"Failed to check device":                                             {LocaleUz: "Qurilmani tekshirib bo'lmadi", LocaleRu: "Не удалось проверить устройство"},
+	"Failed to start verification":                                       {LocaleUz: "Tasdiqlashni boshlab bo'lmadi", LocaleRu: "Не удалось начать проверку"},
+	"Invalid or expired code":                                            {LocaleUz: "Kod noto'g'ri yoki muddati tugagan", LocaleRu: "Неверный или просроченный код"},
+	"Failed to verify code":                                              {LocaleUz: "Kodni tekshirib bo'lmadi", LocaleRu: "Не удалось проверить код"},
+	"Failed to send verification code":                                   {LocaleUz: "Tasdiqlash kodini yuborib bo'lmadi", LocaleRu: "Не удалось отправить код подтверждения"},
+	"Add a phone number to your profile first":                           {LocaleUz: "Avval profilingizga telefon raqam qo'shing", LocaleRu: "Сначала добавьте номер телефона в профиль"},
+	"This phone number is verified by another account":                   {LocaleUz: "Bu telefon raqam boshqa hisobda tasdiqlangan", LocaleRu: "Этот номер телефона подтверждён другим аккаунтом"},
+	"Phone number has changed, request a new code":                       {LocaleUz: "Telefon raqam o'zgargan, yangi kod so'rang", LocaleRu: "Номер телефона изменился, запросите новый код"},
+	"Verification code sent":                                             {LocaleUz: "Tasdiqlash kodi yuborildi", LocaleRu: "Код подтверждения отправлен"},
+	"Phone number verified":                                              {LocaleUz: "Telefon raqam tasdiqlandi", LocaleRu: "Номер телефона подтверждён"},
+	"Link is invalid or expired":                                         {LocaleUz: "Havola noto'g'ri yoki muddati tugagan", LocaleRu: "Ссылка недействительна или устарела"},
+	"All sessions were signed out and your password was changed":         {LocaleUz: "Barcha sessiyalar yopildi va parolingiz o'zgartirildi", LocaleRu: "Все сессии завершены, пароль изменён"},
+	"Account is waiting for verification":                                {LocaleUz: "Hisob tasdiqlanishini kutmoqda", LocaleRu: "Аккаунт ожидает подтверждения"},
+	"Account is suspended":                                               {LocaleUz: "Hisob to'xtatilgan", LocaleRu: "Аккаунт приостановлен"},
+	"Account is deactivated":                                             {LocaleUz: "Hisob o'chirib qo'yilgan", LocaleRu: "Аккаунт деактивирован"},
+	"Account is scheduled for deletion, use /auth/restore to restore it": {LocaleUz: "Hisob o'chirishga rejalashtirilgan, tiklash uchun /auth/restore dan foydalaning", LocaleRu: "Аккаунт запланирован к удалению, восстановить его можно через /auth/restore"},
+	"Account has been deleted":                                           {LocaleUz: "Hisob o'chirilgan", LocaleRu: "Аккаунт удалён"},
+	"Account cannot move from %s to %s":                                  {LocaleUz: "Hisobni %s holatidan %s holatiga o'tkazib bo'lmaydi", LocaleRu: "Аккаунт нельзя перевести из состояния %s в %s"},
+	"until must be a future RFC3339 timestamp":                           {LocaleUz: "until kelajakdagi RFC3339 vaqti bo'lishi kerak", LocaleRu: "until должен быть будущим временем в формате RFC3339"},
+	"You cannot suspend your own account":                                {LocaleUz: "O'z hisobingizni to'xtata olmaysiz", LocaleRu: "Нельзя приостановить собственный аккаунт"},
+	"User suspended successfully":                                        {LocaleUz: "Foydalanuvchi to'xtatildi", LocaleRu: "Пользователь приостановлен"},
+	"User reinstated successfully":                                       {LocaleUz: "Foydalanuvchi tiklandi", LocaleRu: "Пользователь восстановлен"},
+	"Account deactivated successfully":                                   {LocaleUz: "Hisob o'chirib qo'yildi", LocaleRu: "Аккаунт деактивирован"},
+	"Unknown role":                                                       {LocaleUz: "Noma'lum rol", LocaleRu: "Неизвестная роль"},
+	"User role updated successfully":                                     {LocaleUz: "Foydalanuvchi roli yangilandi", LocaleRu: "Роль пользователя обновлена"},
+	"Failed to delete account":                                           {LocaleUz: "Hisobni o'chirib bo'lmadi", LocaleRu: "Не удалось удалить аккаунт"},
+	"Password or a recent login is required":                             {LocaleUz: "Parol yoki yaqinda kirish talab qilinadi", LocaleRu: "Требуется пароль или недавний вход"},
+	"Account cannot be deleted while %s":                                 {LocaleUz: "Hisob %s holatida bo'lganda uni o'chirib bo'lmaydi", LocaleRu: "Аккаунт нельзя удалить в состоянии %s"},
+	"Account scheduled for deletion":                                     {LocaleUz: "Hisob o'chirishga rejalashtirildi", LocaleRu: "Аккаунт запланирован к удалению"},
+	"Account is not scheduled for deletion":                              {LocaleUz: "Hisob o'chirishga rejalashtirilmagan", LocaleRu: "Аккаунт не запланирован к удалению"},
+	"Failed to restore account":                                          {LocaleUz: "Hisobni tiklab bo'lmadi", LocaleRu: "Не удалось восстановить аккаунт"},
+	"Export not found":                                                   {LocaleUz: "Eksport topilmadi", LocaleRu: "Экспорт не найден"},
+	"Export not found or expired":                                        {LocaleUz: "Eksport topilmadi yoki muddati tugagan", LocaleRu: "Экспорт не найден или устарел"},
+	"Download link is invalid or expired":                                {LocaleUz: "Yuklab olish havolasi noto'g'ri yoki muddati tugagan", LocaleRu: "Ссылка для скачивания недействительна или устарела"},
+	"A valid new email is required":                                      {LocaleUz: "Yangi email to'g'ri bo'lishi kerak", LocaleRu: "Укажите корректный новый email"},
+	"Failed to change email":                                             {LocaleUz: "Emailni o'zgartirib bo'lmadi", LocaleRu: "Не удалось изменить email"},
+	"New email is the same as the current one":                           {LocaleUz: "Yangi email joriy email bilan bir xil", LocaleRu: "Новый email совпадает с текущим"},
+	"Confirmation link sent to the new email":                            {LocaleUz: "Tasdiqlash havolasi yangi emailga yuborildi", LocaleRu: "Ссылка для подтверждения отправлена на новый email"},
+	"Failed to confirm email":                                            {LocaleUz: "Emailni tasdiqlab bo'lmadi", LocaleRu: "Не удалось подтвердить email"},
+	"Email changed successfully, please log in again":                    {LocaleUz: "Email o'zgartirildi, qaytadan kiring", LocaleRu: "Email изменён, войдите снова"},
+	"Failed to undo email change":                                        {LocaleUz: "Email o'zgarishini bekor qilib bo'lmadi", LocaleRu: "Не удалось отменить смену email"},
+	"Email change cancelled and all sessions signed out":                 {LocaleUz: "Email o'zgarishi bekor qilindi va barcha sessiyalar yopildi", LocaleRu: "Смена email отменена, все сессии завершены"},
+	"Failed to change username":                                          {LocaleUz: "Username ni o'zgartirib bo'lmadi", LocaleRu: "Не удалось изменить имя пользователя"},
+	"Username changed successfully":                                      {LocaleUz: "Username o'zgartirildi", LocaleRu: "Имя пользователя изменено"},
+	"username must be 3-30 characters: letters, digits, '_' or '.', starting with a letter": {LocaleUz: "username 3-30 belgi bo'lishi kerak: harflar, raqamlar, '_' yoki '.', harf bilan boshlanadi", LocaleRu: "имя пользователя: 3-30 символов, буквы, цифры, '_' или '.', начинается с буквы"},
+	"username is reserved":                       {LocaleUz: "bu username band qilingan", LocaleRu: "это имя пользователя зарезервировано"},
+	"username is already taken":                  {LocaleUz: "bu username band", LocaleRu: "это имя пользователя уже занято"},
+	"cannot change username while impersonating": {LocaleUz: "foydalanuvchi nomidan ishlayotganda username ni o'zgartirib bo'lmaydi", LocaleRu: "нельзя менять имя пользователя в режиме входа от имени пользователя"},
+	"Profile not found":                          {LocaleUz: "Profil topilmadi", LocaleRu: "Профиль не найден"},
+	"Failed to get profile":                      {LocaleUz: "Profilni olib bo'lmadi", LocaleRu: "Не удалось получить профиль"},
+	"Failed to update profile":                   {LocaleUz: "Profilni yangilab bo'lmadi", LocaleRu: "Не удалось обновить профиль"},
+	"Failed to update user":                      {LocaleUz: "Foydalanuvchini yangilab bo'lmadi", LocaleRu: "Не удалось обновить пользователя"},
+	"User updated successfully":                  {LocaleUz: "Foydalanuvchi yangilandi", LocaleRu: "Пользователь обновлён"},
+	"Profile was changed by another request, reload it and try again": {LocaleUz: "Profil boshqa so'rov bilan o'zgartirilgan, qayta yuklab, yana urinib ko'ring", LocaleRu: "Профиль изменён другим запросом, обновите его и повторите попытку"},
+	"If-Match header or version is required":                          {LocaleUz: "If-Match sarlavhasi yoki version talab qilinadi", LocaleRu: "Требуется заголовок If-Match или version"},
+	"If-Match header with the profile ETag is required":               {LocaleUz: "Profil ETag i bilan If-Match sarlavhasi talab qilinadi", LocaleRu: "Требуется заголовок If-Match с ETag профиля"},
+	"If-Match header or current_version is required":                  {LocaleUz: "If-Match sarlavhasi yoki current_version talab qilinadi", LocaleRu: "Требуется заголовок If-Match или current_version"},
+	"Content-Type must be application/merge-patch+json":               {LocaleUz: "Content-Type application/merge-patch+json bo'lishi kerak", LocaleRu: "Content-Type должен быть application/merge-patch+json"},
+	"body must be a JSON object":                                      {LocaleUz: "body JSON obyekt bo'lishi kerak", LocaleRu: "тело запроса должно быть JSON-объектом"},
+	"fullname cannot be empty":                                        {LocaleUz: "fullname bo'sh bo'lmasligi kerak", LocaleRu: "fullname не может быть пустым"},
+	"fullname must be at most 200 characters":                         {LocaleUz: "fullname ko'pi bilan 200 belgi bo'lishi kerak", LocaleRu: "fullname должен быть не длиннее 200 символов"},
+	"date_of_birth must be YYYY-MM-DD":                                {LocaleUz: "date_of_birth YYYY-MM-DD formatida bo'lishi kerak", LocaleRu: "date_of_birth должен быть в формате YYYY-MM-DD"},
+	"date_of_birth must be between 1900 and today":                    {LocaleUz: "date_of_birth 1900 yildan bugungacha bo'lishi kerak", LocaleRu: "date_of_birth должен быть между 1900 годом и сегодняшним днём"},
+	"phone_number must be 7-15 digits, optionally starting with +":    {LocaleUz: "phone_number 7-15 raqam bo'lishi kerak, + bilan boshlanishi mumkin", LocaleRu: "phone_number должен содержать 7-15 цифр, может начинаться с +"},
+	"address must be at most 255 characters":                          {LocaleUz: "address ko'pi bilan 255 belgi bo'lishi kerak", LocaleRu: "address должен быть не длиннее 255 символов"},
+	"locale must be one of uz, ru, en":                                {LocaleUz: "locale uz, ru yoki en bo'lishi kerak", LocaleRu: "locale должен быть uz, ru или en"},
+	"timezone must be an IANA time zone such as Asia/Tashkent":        {LocaleUz: "timezone IANA vaqt zonasi bo'lishi kerak, masalan Asia/Tashkent", LocaleRu: "timezone должен быть часовым поясом IANA, например Asia/Tashkent"},
+	"Failed to list profile history":                                  {LocaleUz: "Profil tarixini olib bo'lmadi", LocaleRu: "Не удалось получить историю профиля"},
+	"Profile did not exist at that time":                              {LocaleUz: "O'sha paytda profil mavjud bo'lmagan", LocaleRu: "В это время профиля ещё не было"},
+	"Profile version not found":                                       {LocaleUz: "Profil versiyasi topilmadi", LocaleRu: "Версия профиля не найдена"},
+	"Failed to revert profile":                                        {LocaleUz: "Profilni qaytarib bo'lmadi", LocaleRu: "Не удалось откатить профиль"},
+	"Profile reverted successfully":                                   {LocaleUz: "Profil qaytarildi", LocaleRu: "Профиль восстановлен"},
+	"Avatar must be at most %d MB":                                    {LocaleUz: "Avatar ko'pi bilan %d MB bo'lishi kerak", LocaleRu: "Аватар должен быть не больше %d МБ"},
+	"Multipart field \"avatar\" is required":                          {LocaleUz: "\"avatar\" multipart maydoni talab qilinadi", LocaleRu: "Требуется multipart-поле \"avatar\""},
+	"Failed to upload avatar":                                         {LocaleUz: "Avatarni yuklab bo'lmadi", LocaleRu: "Не удалось загрузить аватар"},
+	"Failed to delete avatar":                                         {LocaleUz: "Avatarni o'chirib bo'lmadi", LocaleRu: "Не удалось удалить аватар"},
+	"Avatar deleted successfully":                                     {LocaleUz: "Avatar o'chirildi", LocaleRu: "Аватар удалён"},
+	"Failed to load media":                                            {LocaleUz: "Faylni yuklab bo'lmadi", LocaleRu: "Не удалось загрузить файл"},
+	"Failed to update dining preferences":                             {LocaleUz: "Ovqatlanish afzalliklarini yangilab bo'lmadi", LocaleRu: "Не удалось обновить предпочтения"},
+	"Failed to get notification preferences":                          {LocaleUz: "Bildirishnoma sozlamalarini olib bo'lmadi", LocaleRu: "Не удалось получить настройки уведомлений"},
+	"Failed to update notification preferences":                       {LocaleUz: "Bildirishnoma sozlamalarini yangilab bo'lmadi", LocaleRu: "Не удалось обновить настройки уведомлений"},
+	"security notifications cannot be turned off":                     {LocaleUz: "xavfsizlik bildirishnomalarini o'chirib bo'lmaydi", LocaleRu: "уведомления безопасности нельзя отключить"},
+	"unknown notification category":                                   {LocaleUz: "noma'lum bildirishnoma kategoriyasi", LocaleRu: "неизвестная категория уведомлений"},
+	"Failed to list push devices":                                     {LocaleUz: "Push qurilmalar ro'yxatini olib bo'lmadi", LocaleRu: "Не удалось получить список push-устройств"},
+	"Failed to register push device":                                  {LocaleUz: "Push qurilmani ro'yxatdan o'tkazib bo'lmadi", LocaleRu: "Не удалось зарегистрировать push-устройство"},
+	"Failed to refresh push device":                                   {LocaleUz: "Push qurilmani yangilab bo'lmadi", LocaleRu: "Не удалось обновить push-устройство"},
+	"Failed to remove push device":                                    {LocaleUz: "Push qurilmani o'chirib bo'lmadi", LocaleRu: "Не удалось удалить push-устройство"},
+	"Push device not found":                                           {LocaleUz: "Push qurilma topilmadi", LocaleRu: "Push-устройство не найдено"},
+	"Push device removed successfully":                                {LocaleUz: "Push qurilma o'chirildi", LocaleRu: "Push-устройство удалено"},
+	"push device not found":                                           {LocaleUz: "push qurilma topilmadi", LocaleRu: "push-устройство не найдено"},
+	"cannot manage push devices while impersonating":                  {LocaleUz: "foydalanuvchi nomidan ishlayotganda push qurilmalarni boshqarib bo'lmaydi", LocaleRu: "нельзя управлять push-устройствами в режиме входа от имени пользователя"},
+	"provider must be one of fcm, apns":                               {LocaleUz: "provider fcm yoki apns bo'lishi kerak", LocaleRu: "provider должен быть fcm или apns"},
+	"token is not a valid APNs device token":                          {LocaleUz: "token APNs qurilma tokeni emas", LocaleRu: "token не является токеном устройства APNs"},
+	"token is not a valid FCM registration token":                     {LocaleUz: "token FCM ro'yxatdan o'tish tokeni emas", LocaleRu: "token не является регистрационным токеном FCM"},
+	"Failed to check consent":                                         {LocaleUz: "Roziliklarni tekshirib bo'lmadi", LocaleRu: "Не удалось проверить согласия"},
+	"Failed to record consent":                                        {LocaleUz: "Rozilikni yozib bo'lmadi", LocaleRu: "Не удалось сохранить согласие"},
+	"Failed to get consents":                                          {LocaleUz: "Roziliklarni olib bo'lmadi", LocaleRu: "Не удалось получить согласия"},
+	"Failed to get legal documents":                                   {LocaleUz: "Huquqiy hujjatlarni olib bo'lmadi", LocaleRu: "Не удалось получить юридические документы"},
+	"Failed to publish legal document":                                {LocaleUz: "Huquqiy hujjatni e'lon qilib bo'lmadi", LocaleRu: "Не удалось опубликовать юридический документ"},
+	"Failed to build consent report":                                  {LocaleUz: "Roziliklar hisobotini tuzib bo'lmadi", LocaleRu: "Не удалось сформировать отчёт о согласиях"},
+	"Invalid or expired consent token":                                {LocaleUz: "Rozilik tokeni noto'g'ri yoki muddati tugagan", LocaleRu: "Токен согласия недействителен или устарел"},
+	"You must accept the current terms of service and privacy policy": {LocaleUz: "Amaldagi foydalanish shartlari va maxfiylik siyosatini qabul qilishingiz kerak", LocaleRu: "Необходимо принять действующие условия использования и политику конфиденциальности"},
+	"This document version is already published":                      {LocaleUz: "Hujjatning bu versiyasi allaqachon e'lon qilingan", LocaleRu: "Эта версия документа уже опубликована"},
+	"type must be terms_of_service or privacy_policy, version up to 20 letters, digits, '.' or '-', and url an https link": {LocaleUz: "type terms_of_service yoki privacy_policy, version 20 tagacha harf, raqam, '.' yoki '-', url esa https havola bo'lishi kerak", LocaleRu: "type должен быть terms_of_service или privacy_policy, version - до 20 букв, цифр, '.' или '-', а url - ссылкой https"},
+	"Failed to update sharing settings":                   {LocaleUz: "Ulashish sozlamalarini yangilab bo'lmadi", LocaleRu: "Не удалось обновить настройки доступа"},
+	"Role must be owner or staff":                         {LocaleUz: "Rol owner yoki staff bo'lishi kerak", LocaleRu: "Роль должна быть owner или staff"},
+	"Restaurant member updated successfully":              {LocaleUz: "Restoran a'zosi yangilandi", LocaleRu: "Участник ресторана обновлён"},
+	"Restaurant member removed successfully":              {LocaleUz: "Restoran a'zosi olib tashlandi", LocaleRu: "Участник ресторана удалён"},
+	"Restaurant owner access required":                    {LocaleUz: "Restoran egasi huquqi kerak", LocaleRu: "Требуются права владельца ресторана"},
+	"Restaurant membership required":                      {LocaleUz: "Restoran a'zoligi kerak", LocaleRu: "Требуется членство в ресторане"},
+	"Failed to check restaurant membership":               {LocaleUz: "Restoran a'zoligini tekshirib bo'lmadi", LocaleRu: "Не удалось проверить членство в ресторане"},
+	"expires_at must be a future RFC3339 timestamp":       {LocaleUz: "expires_at kelajakdagi RFC3339 vaqti bo'lishi kerak", LocaleRu: "expires_at должен быть будущим временем в формате RFC3339"},
+	"Failed to create API key":                            {LocaleUz: "API kalit yaratib bo'lmadi", LocaleRu: "Не удалось создать API-ключ"},
+	"API key not found":                                   {LocaleUz: "API kalit topilmadi", LocaleRu: "API-ключ не найден"},
+	"API key revoked successfully":                        {LocaleUz: "API kalit bekor qilindi", LocaleRu: "API-ключ отозван"},
+	"Invalid API key":                                     {LocaleUz: "API kalit noto'g'ri", LocaleRu: "Недействительный API-ключ"},
+	"Failed to check API key":                             {LocaleUz: "API kalitni tekshirib bo'lmadi", LocaleRu: "Не удалось проверить API-ключ"},
+	"API key lacks permission %s":                         {LocaleUz: "API kalitda %s ruxsati yo'q", LocaleRu: "У API-ключа нет разрешения %s"},
+	"API key belongs to another restaurant":               {LocaleUz: "API kalit boshqa restoranga tegishli", LocaleRu: "API-ключ принадлежит другому ресторану"},
+	"Name is required and must be at most 100 characters": {LocaleUz: "Nom talab qilinadi va ko'pi bilan 100 belgi bo'lishi kerak", LocaleRu: "Название обязательно и должно быть не длиннее 100 символов"},
+	"Permissions must be a non-empty subset of %s":        {LocaleUz: "Ruxsatlar %s ichidan kamida bitta bo'lishi kerak", LocaleRu: "Разрешения должны быть непустым подмножеством %s"},
+	"Failed to start impersonation":                       {LocaleUz: "Foydalanuvchi nomidan kirib bo'lmadi", LocaleRu: "Не удалось войти от имени пользователя"},
+	"Admins cannot be impersonated":                       {LocaleUz: "Administrator nomidan kirib bo'lmaydi", LocaleRu: "Нельзя войти от имени администратора"},
+	"Not an impersonation session":                        {LocaleUz: "Bu support sessiyasi emas", LocaleRu: "Это не сессия поддержки"},
+	"Failed to stop impersonation":                        {LocaleUz: "Support sessiyasini yopib bo'lmadi", LocaleRu: "Не удалось завершить сессию поддержки"},
+	"Impersonation stopped":                               {LocaleUz: "Support sessiyasi yopildi", LocaleRu: "Сессия поддержки завершена"},
+	"too many requests, retry after %d seconds":           {LocaleUz: "so'rovlar juda ko'p, %d soniyadan keyin qayta urinib ko'ring", LocaleRu: "слишком много запросов, повторите через %d с"},
+	"invalid API key":                                     {LocaleUz: "API kalit noto'g'ri", LocaleRu: "недействительный API-ключ"},
+	"failed to check API key":                             {LocaleUz: "API kalitni tekshirib bo'lmadi", LocaleRu: "не удалось проверить API-ключ"},
+	"invalid token":                                       {LocaleUz: "token noto'g'ri", LocaleRu: "недействительный токен"},
+	"failed to check session":                             {LocaleUz: "sessiyani tekshirib bo'lmadi", LocaleRu: "не удалось проверить сессию"},
+	"session has been revoked":                            {LocaleUz: "sessiya yopilgan", LocaleRu: "сессия отозвана"},
+	"authorization is required":                           {LocaleUz: "avtorizatsiya talab qilinadi", LocaleRu: "требуется авторизация"},
+	"admin access required":                               {LocaleUz: "administrator huquqi kerak", LocaleRu: "требуются права администратора"},
+	"a user token is required":                            {LocaleUz: "foydalanuvchi tokeni kerak", LocaleRu: "требуется токен пользователя"},
+	"access to this user is not allowed":                  {LocaleUz: "bu foydalanuvchiga kirish taqiqlangan", LocaleRu: "доступ к этому пользователю запрещён"},
+	"user not found":                                      {LocaleUz: "foydalanuvchi topilmadi", LocaleRu: "пользователь не найден"},
+	"restaurant_id and user_id are required":              {LocaleUz: "restaurant_id va user_id talab qilinadi", LocaleRu: "требуются restaurant_id и user_id"},
+	"API key cannot read guests of this restaurant":       {LocaleUz: "API kalit bu restoran mehmonlarini o'qiy olmaydi", LocaleRu: "API-ключ не может читать гостей этого ресторана"},
+	"restaurant membership required":                      {LocaleUz: "restoran a'zoligi kerak", LocaleRu: "требуется членство в ресторане"},
+	"profile not found":                                   {LocaleUz: "profil topilmadi", LocaleRu: "профиль не найден"},
+	"profile did not exist at that time":                  {LocaleUz: "o'sha paytda profil mavjud bo'lmagan", LocaleRu: "в это время профиля ещё не было"},
+	"current_version is required":                         {LocaleUz: "current_version talab qilinadi", LocaleRu: "требуется current_version"},
+	"profile version not found":                           {LocaleUz: "profil versiyasi topilmadi", LocaleRu: "версия профиля не найдена"},
+	"profile was changed, current version is %d":          {LocaleUz: "profil o'zgartirilgan, joriy versiya %d", LocaleRu: "профиль изменён, текущая версия %d"},
+	"version is required":                                 {LocaleUz: "version talab qilinadi", LocaleRu: "требуется version"},
+	"session not found":                                   {LocaleUz: "sessiya topilmadi", LocaleRu: "сессия не найдена"},
+
+	// Xatlar
+	"Your DineReserve account will be deleted": {
+		LocaleUz: "DineReserve hisobingiz o'chiriladi",
+		LocaleRu: "Ваш аккаунт DineReserve будет удалён",
+	},
+	"Your account and profile will be permanently deleted on %s. If you change your mind, sign in before then and choose to restore your account.": {
+		LocaleUz: "Hisobingiz va profilingiz %s da butunlay o'chiriladi. Fikringiz o'zgarsa, shu vaqtgacha kiring va hisobni tiklashni tanlang.",
+		LocaleRu: "Ваш аккаунт и профиль будут окончательно удалены %s. Если передумаете, войдите до этого времени и выберите восстановление аккаунта.",
+	},
+	"New sign-in to your DineReserve account": {
+		LocaleUz: "DineReserve hisobingizga yangi kirish",
+		LocaleRu: "Новый вход в ваш аккаунт DineReserve",
+	},
+	"New sign-in to your DineReserve account from %s (IP %s) at %s.\n\nIf this wasn't you, open this link to sign out that device and set a new password:\n%s": {
+		LocaleUz: "DineReserve hisobingizga %s qurilmasidan (IP %s) %s da kirildi.\n\nBu siz bo'lmasangiz, shu qurilmani chiqarish va yangi parol o'rnatish uchun havolani oching:\n%s",
+		LocaleRu: "Новый вход в ваш аккаунт DineReserve с устройства %s (IP %s) в %s.\n\nЕсли это были не вы, откройте ссылку, чтобы завершить сеанс на этом устройстве и задать новый пароль:\n%s",
+	},
+	"DineReserve: new sign-in from %s. Not you? %s": {
+		LocaleUz: "DineReserve: %s dan yangi kirish. Siz emasmi? %s",
+		LocaleRu: "DineReserve: новый вход с %s. Это не вы? %s",
+	},
+	"Your DineReserve sign-in code": {
+		LocaleUz: "DineReserve kirish kodingiz",
+		LocaleRu: "Ваш код входа в DineReserve",
+	},
+	"Someone is signing in to your account from a new device. Your code is %s. It expires in 10 minutes.": {
+		LocaleUz: "Kimdir hisobingizga yangi qurilmadan kirmoqda. Kodingiz: %s. U 10 daqiqa amal qiladi.",
+		LocaleRu: "Кто-то входит в ваш аккаунт с нового устройства. Ваш код: %s. Он действует 10 минут.",
+	},
+	"Confirm your new DineReserve email": {
+		LocaleUz: "DineReserve uchun yangi emailingizni tasdiqlang",
+		LocaleRu: "Подтвердите новый email для DineReserve",
+	},
+	"Open this link within 24 hours to use this address for your DineReserve account:\n%s/account/confirm-email?token=%s": {
+		LocaleUz: "Bu manzilni DineReserve hisobingiz uchun ishlatish uchun havolani 24 soat ichida oching:\n%s/account/confirm-email?token=%s",
+		LocaleRu: "Откройте ссылку в течение 24 часов, чтобы использовать этот адрес для аккаунта DineReserve:\n%s/account/confirm-email?token=%s",
+	},
+	"Your DineReserve email is being changed": {
+		LocaleUz: "DineReserve emailingiz o'zgartirilmoqda",
+		LocaleRu: "Email вашего аккаунта DineReserve меняется",
+	},
+	"Someone asked to change the email of your DineReserve account to %s. If this wasn't you, open this link to cancel the change and sign out all devices:\n%s/account/undo-email?token=%s": {
+		LocaleUz: "Kimdir DineReserve hisobingiz emailini %s ga o'zgartirishni so'radi. Bu siz bo'lmasangiz, o'zgarishni bekor qilish va barcha qurilmalardan chiqish uchun havolani oching:\n%s/account/undo-email?token=%s",
+		LocaleRu: "Кто-то запросил смену email вашего аккаунта DineReserve на %s. Если это были не вы, откройте ссылку, чтобы отменить смену и завершить все сеансы:\n%s/account/undo-email?token=%s",
+	},
+	"DineReserve support accessed your account": {
+		LocaleUz: "DineReserve support xizmati hisobingizga kirdi",
+		LocaleRu: "Поддержка DineReserve вошла в ваш аккаунт",
+	},
+	"A DineReserve support agent signed in to your account at your request to investigate a problem. The access ends automatically within %d minutes and appears in your list of active sessions.": {
+		LocaleUz: "DineReserve support xodimi muammoni tekshirish uchun so'rovingizga ko'ra hisobingizga kirdi. Kirish %d daqiqa ichida avtomatik tugaydi va faol sessiyalar ro'yxatida ko'rinadi.",
+		LocaleRu: "Сотрудник поддержки DineReserve вошёл в ваш аккаунт по вашей просьбе, чтобы разобраться с проблемой. Доступ автоматически закончится в течение %d минут и отображается в списке активных сеансов.",
+	},
+	"Your DineReserve data export is ready": {
+		LocaleUz: "DineReserve ma'lumotlaringiz eksporti tayyor",
+		LocaleRu: "Экспорт ваших данных DineReserve готов",
+	},
+	"The copy of your DineReserve data you asked for is ready. Download it here:\n%s\n\nThe link expires on %s.": {
+		LocaleUz: "So'ragan DineReserve ma'lumotlaringiz nusxasi tayyor. Uni bu yerdan yuklab oling:\n%s\n\nHavola %s da eskiradi.",
+		LocaleRu: "Копия ваших данных DineReserve готова. Скачайте её здесь:\n%s\n\nСсылка действительна до %s.",
+	},
+	"DineReserve: your phone verification code is %s. It expires in 10 minutes.": {
+		LocaleUz: "DineReserve: telefonni tasdiqlash kodingiz %s. U 10 daqiqa amal qiladi.",
+		LocaleRu: "DineReserve: ваш код подтверждения телефона %s. Он действует 10 минут.",
+	},
+	"Verify your DineReserve email": {
+		LocaleUz: "DineReserve emailingizni tasdiqlang",
+		LocaleRu: "Подтвердите email для DineReserve",
+	},
+	"Open this link within 24 hours to activate your DineReserve account:\n%s/account/verify-email?token=%s": {
+		LocaleUz: "DineReserve hisobingizni faollashtirish uchun bu havolani 24 soat ichida oching:\n%s/account/verify-email?token=%s",
+		LocaleRu: "Откройте эту ссылку в течение 24 часов, чтобы активировать аккаунт DineReserve:\n%s/account/verify-email?token=%s",
+	},
+	"User created, check your email to activate the account": {
+		LocaleUz: "Foydalanuvchi yaratildi, hisobni faollashtirish uchun emailingizni tekshiring",
+		LocaleRu: "Пользователь создан, проверьте email, чтобы активировать аккаунт",
+	},
+	"Email verified, you can log in now": {
+		LocaleUz: "Email tasdiqlandi, endi tizimga kirishingiz mumkin",
+		LocaleRu: "Email подтверждён, теперь вы можете войти",
+	},
+	"Failed to verify email": {
+		LocaleUz: "Emailni tasdiqlab bo'lmadi",
+		LocaleRu: "Не удалось подтвердить email",
+	},
+	"If the account is waiting for verification, a new link has been sent": {
+		LocaleUz: "Agar hisob tasdiqlanishni kutayotgan bo'lsa, yangi havola yuborildi",
+		LocaleRu: "Если аккаунт ожидает подтверждения, новая ссылка отправлена",
+	},
+}
@@ -0,0 +1,102 @@
+package i18n
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata"
+)
+
+// Qo'llab-quvvatlanadigan tillar
+const (
+	LocaleUz = "uz"
+	LocaleRu = "ru"
+	LocaleEn = "en"
+
+	Default = LocaleEn
+)
+
+var Locales = []string{LocaleUz, LocaleRu, LocaleEn}
+
+func Supported(locale string) bool {
+	return slices.Contains(Locales, locale)
+}
+
+// Negotiate Accept-Language sarlavhasidan eng mos tilni q qiymati bo'yicha
+// tanlaydi. Mos til bo'lmasa bo'sh satr.
+func Negotiate(header string) string {
+	type choice struct {
+		locale string
+		q      float64
+	}
+
+	var choices []choice
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		// uz-Latn-UZ -> uz
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if q > 0 && Supported(base) {
+			choices = append(choices, choice{base, q})
+		}
+	}
+
+	sort.SliceStable(choices, func(i, j int) bool {
+		return choices[i].q > choices[j].q
+	})
+	if len(choices) == 0 {
+		return ""
+	}
+	return choices[0].locale
+}
+
+// Resolve birinchi qo'llab-quvvatlanadigan tilni qaytaradi, bo'lmasa Default
+func Resolve(candidates ...string) string {
+	for _, locale := range candidates {
+		if Supported(locale) {
+			return locale
+		}
+	}
+	return Default
+}
+
+// T inglizcha xabarni tarjima qiladi va args bilan formatlaydi. Katalogda
+// bo'lmagan xabar inglizcha qoladi.
+func T(locale, msg string, args ...interface{}) string {
+	if translated, ok := catalog[msg][locale]; ok {
+		msg = translated
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// ValidTimezone IANA vaqt zonasi nomi ekanini tekshiradi
+func ValidTimezone(tz string) bool {
+	if tz == "" || tz == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(tz)
+	return err == nil
+}
+
+// FormatTime vaqtni foydalanuvchi zonasida ko'rsatadi. Zona noma'lum
+// bo'lsa UTC.
+func FormatTime(t time.Time, tz string) string {
+	loc := time.UTC
+	if ValidTimezone(tz) {
+		loc, _ = time.LoadLocation(tz)
+	}
+	return t.In(loc).Format("2006-01-02 15:04 MST")
+}
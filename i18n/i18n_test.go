@@ -0,0 +1,46 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	for header, want := range map[string]string{
+		"ru-RU,en;q=0.8":         LocaleRu,
+		"fr, en;q=0.5, uz;q=0.9": LocaleUz,
+		"uz-Latn-UZ":             LocaleUz,
+		"en;q=0, ru;q=0.1":       LocaleRu,
+		"fr":                     "",
+		"":                       "",
+	} {
+		assert.Equal(t, want, Negotiate(header), header)
+	}
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "Пользователь не найден", T(LocaleRu, "User not found"))
+	assert.Equal(t, "User not found", T(LocaleEn, "User not found"))
+	assert.Equal(t, "Avatar ko'pi bilan 5 MB bo'lishi kerak", T(LocaleUz, "Avatar must be at most %d MB", 5))
+	assert.Equal(t, "Unknown message 100%", T(LocaleRu, "Unknown message 100%"))
+}
+
+func TestCatalog(t *testing.T) {
+	for msg, translations := range catalog {
+		for _, locale := range []string{LocaleUz, LocaleRu} {
+			translated, ok := translations[locale]
+			if assert.True(t, ok, "%s: %q", locale, msg) {
+				assert.Equal(t, strings.Count(msg, "%"), strings.Count(translated, "%"), "%s: %q", locale, msg)
+			}
+		}
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	at := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2026-01-02 15:00 +05", FormatTime(at, "Asia/Tashkent"))
+	assert.Equal(t, "2026-01-02 10:00 UTC", FormatTime(at, "nowhere"))
+}
@@ -0,0 +1,261 @@
+package jobs
+
+import (
+	"archive/zip"
+	"auth-service/auth/token"
+	"auth-service/config"
+	pb "auth-service/generated/auth_service"
+	"auth-service/i18n"
+	"auth-service/notify"
+	"auth-service/storage/postgres"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ExportWorker shaxsiy ma'lumotlar eksportlarini fonda tayyorlaydi
+type ExportWorker struct {
+	Exports     *postgres.ExportRepo
+	Users       *postgres.UserRepo
+	Sessions    *postgres.SessionRepo
+	LoginEvents *postgres.LoginEventRepo
+	Consents    *postgres.ConsentRepo
+	Notifier    notify.Notifier
+	Config      config.Config
+	Logger      *slog.Logger
+	Interval    time.Duration
+}
+
+// DownloadURL eksport uchun imzolangan, muddatli yuklab olish havolasi
+func DownloadURL(cfg config.Config, exportId string, expiresAt time.Time) string {
+	expires := expiresAt.Unix()
+	return fmt.Sprintf("%s/auth/exports/%s/download?expires=%d&signature=%s", cfg.API_URL, url.PathEscape(exportId),
+		expires, token.SignLink(cfg.LINK_SIGNING_KEY, exportId, expires))
+}
+
+// Run ctx bekor qilinguncha navbatdagi eksportlarni qayta ishlaydi
+func (w *ExportWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	for {
+		for w.processNext(ctx) {
+		}
+		w.expireOld()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// processNext bitta eksportni tayyorlaydi. Navbat bo'sh bo'lsa false.
+func (w *ExportWorker) processNext(ctx context.Context) bool {
+	job, err := w.Exports.ClaimNext(time.Duration(w.Config.EXPORT_LEASE_MINUTES) * time.Minute)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false
+	}
+	if err != nil {
+		w.Logger.Error("Error claiming data export", "error", err.Error())
+		return false
+	}
+
+	path, err := w.buildArchive(job)
+	if err != nil {
+		w.Logger.Error("Error building data export", "export_id", job.Id, "error", err.Error())
+		if err := w.Exports.FailExport(job.Id, err.Error()); err != nil {
+			w.Logger.Error("Error marking data export failed", "export_id", job.Id, "error", err.Error())
+		}
+		return true
+	}
+
+	expiresAt := time.Now().Add(time.Duration(w.Config.EXPORT_TTL_HOURS) * time.Hour)
+	if err := w.Exports.CompleteExport(job.Id, path, expiresAt); err != nil {
+		w.Logger.Error("Error completing data export", "export_id", job.Id, "error", err.Error())
+		return true
+	}
+
+	user, err := w.Users.GetUserById(job.UserId)
+	if err != nil {
+		w.Logger.Error("Error getting user for export notification", "export_id", job.Id, "error", err.Error())
+		return true
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	err = w.Notifier.Send(sendCtx, notify.Message{
+		Channel: notify.ChannelEmail,
+		To:      user.Email,
+		Subject: i18n.T(user.Locale, "Your DineReserve data export is ready"),
+		Body: i18n.T(user.Locale, "The copy of your DineReserve data you asked for is ready. Download it here:\n%s\n\nThe link expires on %s.",
+			DownloadURL(w.Config, job.Id, expiresAt), i18n.FormatTime(expiresAt, user.Timezone)),
+	})
+	if err != nil {
+		w.Logger.Error("Error sending export notification", "export_id", job.Id, "error", err.Error())
+	}
+	return true
+}
+
+// buildArchive foydalanuvchi ma'lumotlarini JSON fayllar sifatida ZIP ga yozadi
+func (w *ExportWorker) buildArchive(job *postgres.ClaimedExport) (string, error) {
+	files, err := w.collect(job.UserId)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(w.Config.EXPORT_DIR, 0o700); err != nil {
+		return "", err
+	}
+	path := filepath.Join(w.Config.EXPORT_DIR, job.Id+".zip")
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+	for _, file := range files {
+		fw, err := zw.Create(file.name)
+		if err != nil {
+			return "", err
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.data); err != nil {
+			return "", err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+
+	return path, f.Close()
+}
+
+type archiveFile struct {
+	name string
+	data interface{}
+}
+
+func (w *ExportWorker) collect(userId string) ([]archiveFile, error) {
+	account, err := w.Users.GetUserSummary(userId)
+	if err != nil {
+		return nil, err
+	}
+	status, err := w.Users.GetAccountStatus(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := w.Users.GetUserProfileById(userId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	usernames, err := w.Users.ListUsernameHistory(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	dining, err := w.Users.GetDiningPreferences(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := w.Users.GetNotificationPreferences(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	sharing, err := w.Users.GetSharingSettings(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*pb.ProfileVersion
+	for offset := int32(0); ; offset += 100 {
+		page, err := w.Users.ListProfileHistory(&pb.ListProfileHistoryRequest{UserId: userId, Limit: 100, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, page.Versions...)
+		if len(page.Versions) < 100 {
+			break
+		}
+	}
+
+	sessions, err := w.Sessions.ListSessions(&pb.ListSessionsRequest{UserId: userId})
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := w.Sessions.ListPushDevices(userId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	consents, err := w.Consents.ListConsents(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*pb.LoginEvent
+	for offset := int32(0); ; offset += 100 {
+		page, err := w.LoginEvents.ListLoginEvents(&pb.ListLoginEventsRequest{UserId: userId, Limit: 100, Offset: offset})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, page.Events...)
+		if len(page.Events) < 100 {
+			break
+		}
+	}
+
+	return []archiveFile{
+		{"manifest.json", map[string]string{
+			"user_id":      userId,
+			"generated_at": time.Now().UTC().Format(time.RFC3339),
+		}},
+		{"account.json", map[string]interface{}{
+			"user":   account,
+			"status": status,
+		}},
+		{"profile.json", profile},
+		{"profile_history.json", versions},
+		{"username_history.json", usernames},
+		{"dining_preferences.json", dining},
+		{"notification_preferences.json", notifications},
+		{"guest_sharing.json", sharing},
+		{"sessions.json", sessions.Sessions},
+		{"push_devices.json", devices},
+		{"login_history.json", events},
+		{"consents.json", consents},
+		// Tashqi (social) login hozircha yo'q
+		{"external_identities.json", []interface{}{}},
+	}, nil
+}
+
+// expireOld muddati o'tgan eksport fayllarini diskdan o'chiradi
+func (w *ExportWorker) expireOld() {
+	paths, err := w.Exports.ExpireExports()
+	if err != nil {
+		w.Logger.Error("Error expiring data exports", "error", err.Error())
+		return
+	}
+
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			w.Logger.Error("Error removing data export file", "path", path, "error", err.Error())
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"auth-service/audit"
+	"auth-service/avatar"
+	"auth-service/storage/blob"
+	"auth-service/storage/postgres"
+	"context"
+	"log/slog"
+	"time"
+)
+
+const purgeBatchSize = 100
+
+// AccountPurger grace muddati tugagan hisoblarni vaqti-vaqti bilan
+// anonimlashtiradi
+type AccountPurger struct {
+	Users    *postgres.UserRepo
+	Audit    *postgres.AuditRepo
+	Blobs    blob.Store
+	Logger   *slog.Logger
+	Interval time.Duration
+}
+
+// Run ctx bekor qilinguncha ishlaydi
+func (p *AccountPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.Interval)
+	defer ticker.Stop()
+
+	for {
+		p.PurgeDue()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeDue bitta partiyani qayta ishlaydi va nechta hisob o'chirilganini qaytaradi
+func (p *AccountPurger) PurgeDue() int {
+	ids, err := p.Users.ListDueDeletions(purgeBatchSize)
+	if err != nil {
+		p.Logger.Error("Error listing due account deletions", "error", err.Error())
+		return 0
+	}
+
+	purged := 0
+	for _, id := range ids {
+		ok, err := p.Users.PurgeIfDue(id)
+		if err != nil {
+			p.Logger.Error("Error purging account", "user_id", id, "error", err.Error())
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		purged++
+		if err := p.Blobs.DeletePrefix(context.Background(), avatar.Prefix(id, "")); err != nil {
+			p.Logger.Error("Error deleting avatar files", "user_id", id, "error", err.Error())
+		}
+		err = p.Audit.Append(&audit.Entry{
+			Action:     audit.ActionAccountPurge,
+			TargetType: "user",
+			TargetId:   id,
+		})
+		if err != nil {
+			p.Logger.Error("Error writing audit log", "action", audit.ActionAccountPurge, "error", err.Error())
+		}
+	}
+
+	if purged > 0 {
+		p.Logger.Info("Purged deleted accounts", "count", purged)
+	}
+	return purged
+}
@@ -9,10 +9,203 @@ type Success struct {
 }
 
 type Token struct {
-	AccessToken string `json:"access_token"`
+	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 }
 
 type Request struct {
 	RefreshToken string `json:"refresh_token"`
-}
\ No newline at end of file
+}
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+type StepUpChallenge struct {
+	ChallengeId string `json:"challenge_id"`
+	Message     string `json:"message"`
+}
+
+type VerifyLoginRequest struct {
+	ChallengeId string `json:"challenge_id"`
+	Code        string `json:"code"`
+}
+
+type PhoneVerification struct {
+	ChallengeId string `json:"challenge_id"`
+	Message     string `json:"message"`
+}
+
+type ConfirmPhoneRequest struct {
+	ChallengeId string `json:"challenge_id"`
+	Code        string `json:"code"`
+}
+
+type DenyLoginRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+type APIKeyPrincipal struct {
+	APIKeyId     string   `json:"api_key_id"`
+	RestaurantId string   `json:"restaurant_id"`
+	Permissions  []string `json:"permissions"`
+}
+
+type SuspendUserRequest struct {
+	Reason string `json:"reason"`
+	Until  string `json:"until"` // RFC3339, bo'sh bo'lsa muddatsiz
+}
+
+type StatusChangeRequest struct {
+	Reason string `json:"reason"`
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password"` // Yaqinda kirilgan bo'lsa shart emas
+}
+
+type AccountDeletionScheduled struct {
+	Message  string `json:"message"`
+	DeleteAt string `json:"delete_at"`
+}
+
+type ChangeEmailRequest struct {
+	NewEmail string `json:"new_email"`
+	Password string `json:"password"`
+}
+
+// EmailTokenRequest email tasdiqlash yoki bekor qilish havolasidagi token
+type EmailTokenRequest struct {
+	Token string `json:"token"`
+}
+
+// ResendVerificationRequest tasdiqlash havolasini qayta yuborish uchun email
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+type ChangeUsernameRequest struct {
+	Username string `json:"username"`
+}
+
+type Avatar struct {
+	AvatarUrl string            `json:"avatar_url"`
+	Variants  map[string]string `json:"variants"` // o'lcham -> URL
+}
+
+// DiningVocabulary ovqatlanish afzalliklari uchun ruxsat etilgan qiymatlar
+type DiningVocabulary struct {
+	DietaryRestrictions []string `json:"dietary_restrictions"`
+	Allergies           []string `json:"allergies"`
+	Seating             []string `json:"seating"`
+	FavoriteCuisines    []string `json:"favorite_cuisines"`
+	Occasions           []string `json:"occasions"`
+}
+
+// UpdateProfileRequest auth_service.UpdateUserProfileRequest ning swagger
+// ko'rinishi, swag google.protobuf.FieldMask ni o'qiy olmaydi
+type UpdateProfileRequest struct {
+	FullName    string     `json:"fullName"`
+	DateOfBirth string     `json:"date_of_birth"`
+	PhoneNumber string     `json:"phone_number"`
+	Address     string     `json:"address"`
+	Locale      string     `json:"locale"`
+	Timezone    string     `json:"timezone"`
+	UpdateMask  *FieldMask `json:"update_mask"`
+	Version     int64      `json:"version"`
+}
+
+type FieldMask struct {
+	Paths []string `json:"paths"`
+}
+
+// RevertProfileRequest current_version o'rniga If-Match ham yuborish mumkin
+type RevertProfileRequest struct {
+	Version        int64 `json:"version"`
+	CurrentVersion int64 `json:"current_version"`
+}
+
+// LegalDocument huquqiy hujjatning bitta versiyasi
+type LegalDocument struct {
+	Id          string `json:"id"`
+	Type        string `json:"type"` // terms_of_service, privacy_policy
+	Version     string `json:"version"`
+	Url         string `json:"url"`
+	PublishedAt string `json:"published_at"`
+}
+
+type PublishLegalDocumentRequest struct {
+	Type    string `json:"type"`
+	Version string `json:"version"`
+	Url     string `json:"url"`
+}
+
+type LegalDocuments struct {
+	Documents []*LegalDocument `json:"documents"`
+}
+
+// Consent foydalanuvchi hujjat versiyasini qachon va qayerdan qabul qilgani
+type Consent struct {
+	DocumentId string `json:"document_id"`
+	Type       string `json:"type"`
+	Version    string `json:"version"`
+	AcceptedAt string `json:"accepted_at"`
+	Ip         string `json:"ip"`
+	UserAgent  string `json:"user_agent"`
+}
+
+type MyConsents struct {
+	Accepted []*Consent       `json:"accepted"`
+	Pending  []*LegalDocument `json:"pending"`
+}
+
+type AcceptConsentRequest struct {
+	DocumentIds []string `json:"document_ids"`
+}
+
+// ConsentChallenge login yangi hujjat versiyalari qabul qilinishini kutmoqda
+type ConsentChallenge struct {
+	ChallengeId string           `json:"challenge_id"`
+	Token       string           `json:"token"`
+	Message     string           `json:"message"`
+	Documents   []*LegalDocument `json:"documents"`
+}
+
+type LoginConsentRequest struct {
+	ChallengeId string   `json:"challenge_id"`
+	Token       string   `json:"token"`
+	DocumentIds []string `json:"document_ids"`
+}
+
+type ConsentReportEntry struct {
+	DocumentId     string  `json:"document_id"`
+	Type           string  `json:"type"`
+	Version        string  `json:"version"`
+	Url            string  `json:"url"`
+	PublishedAt    string  `json:"published_at"`
+	Current        bool    `json:"current"`
+	Accepted       int64   `json:"accepted"`
+	Users          int64   `json:"users"` // O'chirilmagan hisoblar
+	AcceptanceRate float64 `json:"acceptance_rate"`
+}
+
+type ConsentReport struct {
+	Documents []*ConsentReportEntry `json:"documents"`
+}
+
+// GuestAccess restoran dinerning mehmon profilini qachon ko'rgani. Xodim
+// va audit yozuvi ma'lumotlari dinerga ko'rsatilmaydi.
+type GuestAccess struct {
+	RestaurantId   string   `json:"restaurant_id"`
+	RestaurantName string   `json:"restaurant_name"`
+	Action         string   `json:"action"`
+	Fields         []string `json:"fields"`
+	AccessedAt     string   `json:"accessed_at"`
+}
+
+type GuestAccessLog struct {
+	Accesses []*GuestAccess `json:"accesses"`
+	Total    int64          `json:"total"`
+}
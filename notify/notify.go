@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"auth-service/config"
+	"auth-service/logs"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+type Message struct {
+	Channel string
+	To      string
+	Subject string
+	Body    string
+}
+
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New konfiguratsiyada sozlangan kanallar bo'yicha xabar yuboruvchini
+// qaytaradi. Sozlanmagan kanal xabarlari faqat logga yoziladi.
+func New(cfg config.Config) Notifier {
+	d := &Dispatcher{senders: map[string]Notifier{}}
+
+	if cfg.SMTP_HOST != "" {
+		d.senders[ChannelEmail] = &SMTPNotifier{
+			Addr:     fmt.Sprintf("%s:%d", cfg.SMTP_HOST, cfg.SMTP_PORT),
+			Host:     cfg.SMTP_HOST,
+			User:     cfg.SMTP_USER,
+			Password: cfg.SMTP_PASSWORD,
+			From:     cfg.SMTP_FROM,
+		}
+	}
+	if cfg.SMS_WEBHOOK_URL != "" {
+		d.senders[ChannelSMS] = &WebhookNotifier{
+			URL:    cfg.SMS_WEBHOOK_URL,
+			Client: &http.Client{Timeout: 10 * time.Second},
+		}
+	}
+
+	return d
+}
+
+// Dispatcher xabarni kanaliga mos yuboruvchiga uzatadi
+type Dispatcher struct {
+	senders map[string]Notifier
+}
+
+func (d *Dispatcher) Send(ctx context.Context, msg Message) error {
+	sender, ok := d.senders[msg.Channel]
+	if !ok {
+		return LogNotifier{}.Send(ctx, msg)
+	}
+	return sender.Send(ctx, msg)
+}
+
+// LogNotifier lokal ishlab chiqish uchun xabarni logga yozadi
+type LogNotifier struct{}
+
+func (LogNotifier) Send(ctx context.Context, msg Message) error {
+	logs.Logger.Info("Notification", "channel", msg.Channel, "to", msg.To, "subject", msg.Subject, "body", msg.Body)
+	return nil
+}
+
+type SMTPNotifier struct {
+	Addr     string
+	Host     string
+	User     string
+	Password string
+	From     string
+}
+
+func (s *SMTPNotifier) Send(ctx context.Context, msg Message) error {
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return errors.New("invalid email header")
+	}
+
+	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		s.From, msg.To, msg.Subject, msg.Body)
+
+	var auth smtp.Auth
+	if s.User != "" {
+		auth = smtp.PlainAuth("", s.User, s.Password, s.Host)
+	}
+	return smtp.SendMail(s.Addr, auth, s.From, []string{msg.To}, []byte(body))
+}
+
+// WebhookNotifier SMS provayderiga {"to", "text"} JSON yuboradi
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func (w *WebhookNotifier) Send(ctx context.Context, msg Message) error {
+	payload, err := json.Marshal(map[string]string{
+		"to":   msg.To,
+		"text": msg.Body,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sms webhook returned %s", resp.Status)
+	}
+	return nil
+}
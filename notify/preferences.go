@@ -0,0 +1,100 @@
+package notify
+
+import (
+	pb "auth-service/generated/auth_service"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	ChannelTelegram = "telegram"
+	ChannelPush     = "push"
+)
+
+// Xabar kategoriyalari
+const (
+	CategorySecurity             = "security"
+	CategoryReservationReminders = "reservation_reminders"
+	CategoryMarketing            = "marketing"
+)
+
+var (
+	Categories = []string{CategorySecurity, CategoryReservationReminders, CategoryMarketing}
+	Channels   = []string{ChannelEmail, ChannelSMS, ChannelTelegram, ChannelPush}
+)
+
+// Locked kategoriyani foydalanuvchi o'chira olmaydi
+func Locked(category string) bool {
+	return category == CategorySecurity
+}
+
+// Default saqlanmagan sozlama qiymati. Marketing faqat rozilik bilan.
+func Default(category, channel string) bool {
+	switch category {
+	case CategorySecurity:
+		return true
+	case CategoryReservationReminders:
+		return channel == ChannelEmail || channel == ChannelPush
+	}
+	return false
+}
+
+// Normalize qiymatlarni kichik harfga o'tkazadi va tekshiradi. Qulflangan
+// kategoriyalar ro'yxatdan olib tashlanadi, ularni o'chirish esa xato.
+func Normalize(prefs []*pb.NotificationPreference) ([]*pb.NotificationPreference, error) {
+	out := []*pb.NotificationPreference{}
+	seen := map[string]bool{}
+	for _, p := range prefs {
+		p.Category = strings.ToLower(strings.TrimSpace(p.Category))
+		p.Channel = strings.ToLower(strings.TrimSpace(p.Channel))
+		if !slices.Contains(Categories, p.Category) {
+			return nil, fmt.Errorf("category: unknown value %q", p.Category)
+		}
+		if !slices.Contains(Channels, p.Channel) {
+			return nil, fmt.Errorf("channel: unknown value %q", p.Channel)
+		}
+
+		key := p.Category + "/" + p.Channel
+		if seen[key] {
+			return nil, fmt.Errorf("%s is listed more than once", key)
+		}
+		seen[key] = true
+
+		if Locked(p.Category) {
+			if !p.Enabled {
+				return nil, fmt.Errorf("%s notifications cannot be turned off", p.Category)
+			}
+			continue
+		}
+		p.Locked = false
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// Resolve saqlangan sozlamalarni standart qiymatlar bilan to'ldirib har bir
+// kategoriya va kanal uchun yakuniy qiymatni qaytaradi
+func Resolve(stored []*pb.NotificationPreference) []*pb.NotificationPreference {
+	enabled := map[string]bool{}
+	for _, p := range stored {
+		enabled[p.Category+"/"+p.Channel] = p.Enabled
+	}
+
+	out := make([]*pb.NotificationPreference, 0, len(Categories)*len(Channels))
+	for _, category := range Categories {
+		for _, channel := range Channels {
+			p := &pb.NotificationPreference{
+				Category: category,
+				Channel:  channel,
+				Enabled:  Default(category, channel),
+				Locked:   Locked(category),
+			}
+			if v, ok := enabled[category+"/"+channel]; ok && !p.Locked {
+				p.Enabled = v
+			}
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package notify
+
+import (
+	pb "auth-service/generated/auth_service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePreferences(t *testing.T) {
+	prefs, err := Normalize([]*pb.NotificationPreference{
+		{Category: " Marketing", Channel: "Telegram", Enabled: true},
+		{Category: "security", Channel: "sms", Enabled: true},
+	})
+	require.NoError(t, err)
+	assert.Len(t, prefs, 1)
+	assert.Equal(t, "marketing", prefs[0].Category)
+	assert.Equal(t, "telegram", prefs[0].Channel)
+
+	for _, p := range [][]*pb.NotificationPreference{
+		{{Category: "news", Channel: "email"}},
+		{{Category: "marketing", Channel: "fax"}},
+		{{Category: "security", Channel: "email", Enabled: false}},
+		{{Category: "marketing", Channel: "sms"}, {Category: "marketing", Channel: "SMS"}},
+	} {
+		_, err := Normalize(p)
+		assert.Error(t, err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	prefs := Resolve([]*pb.NotificationPreference{
+		{Category: CategoryMarketing, Channel: ChannelEmail, Enabled: true},
+		{Category: CategorySecurity, Channel: ChannelSMS, Enabled: false},
+	})
+	assert.Len(t, prefs, len(Categories)*len(Channels))
+
+	enabled := map[string]bool{}
+	for _, p := range prefs {
+		enabled[p.Category+"/"+p.Channel] = p.Enabled
+		assert.Equal(t, p.Category == CategorySecurity, p.Locked)
+	}
+	assert.True(t, enabled["marketing/email"])
+	assert.False(t, enabled["marketing/sms"])
+	assert.True(t, enabled["security/sms"])
+	assert.True(t, enabled["reservation_reminders/push"])
+	assert.False(t, enabled["reservation_reminders/telegram"])
+}
@@ -0,0 +1,47 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Push provayderlari
+const (
+	ProviderFCM  = "fcm"
+	ProviderAPNs = "apns"
+)
+
+var Providers = []string{ProviderFCM, ProviderAPNs}
+
+const maxPushTokenLen = 4096
+
+var (
+	// FCM tokeni base64url, ':' va '-' dan iborat
+	fcmToken = regexp.MustCompile(`^[A-Za-z0-9_:\-]{32,}$`)
+	// APNs tokeni hex, hozir 64 belgi, Apple uzunlikni o'zgartirishi mumkin
+	apnsToken = regexp.MustCompile(`^[0-9a-f]{64,200}$`)
+)
+
+// NormalizePushToken provayder va tokenni tekshiradi. APNs tokeni kichik
+// harfga o'tkaziladi.
+func NormalizePushToken(provider, token string) (string, string, error) {
+	provider = strings.ToLower(strings.TrimSpace(provider))
+	token = strings.TrimSpace(token)
+	if !slices.Contains(Providers, provider) {
+		return "", "", fmt.Errorf("provider must be one of %s", strings.Join(Providers, ", "))
+	}
+
+	if provider == ProviderAPNs {
+		token = strings.ToLower(token)
+		if !apnsToken.MatchString(token) {
+			return "", "", errors.New("token is not a valid APNs device token")
+		}
+	} else if len(token) > maxPushTokenLen || !fcmToken.MatchString(token) {
+		return "", "", errors.New("token is not a valid FCM registration token")
+	}
+
+	return provider, token, nil
+}
@@ -0,0 +1,31 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePushToken(t *testing.T) {
+	provider, token, err := NormalizePushToken(" APNS", strings.Repeat("AB", 32))
+	require.NoError(t, err)
+	assert.Equal(t, ProviderAPNs, provider)
+	assert.Equal(t, strings.Repeat("ab", 32), token)
+
+	_, token, err = NormalizePushToken("fcm", " dGVzdA:APA91bH-"+strings.Repeat("x", 100)+" ")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "dGVzdA:"))
+
+	for _, c := range []struct{ provider, token string }{
+		{"gcm", strings.Repeat("a", 64)},
+		{"apns", "xyz"},
+		{"apns", strings.Repeat("g", 64)},
+		{"fcm", "short"},
+		{"fcm", strings.Repeat("a", 40) + " " + strings.Repeat("a", 40)},
+	} {
+		_, _, err := NormalizePushToken(c.provider, c.token)
+		assert.Error(t, err, c.provider)
+	}
+}
@@ -0,0 +1,32 @@
+package profile
+
+import pb "auth-service/generated/auth_service"
+
+// O'zgarish kanallari, profile_history.channel
+const (
+	ChannelAPI    = "api"    // Foydalanuvchining o'zi HTTP orqali
+	ChannelAdmin  = "admin"  // Admin panel
+	ChannelGRPC   = "grpc"   // Ichki servislar
+	ChannelSignup = "signup" // Ro'yxatdan o'tishda yaratilgan
+)
+
+// Change profil o'zgarishini kim va qaysi kanal orqali qilgani.
+// RevertedFrom oldingi versiyaga qaytarishda manba versiya.
+type Change struct {
+	ActorId      string
+	Channel      string
+	RevertedFrom int64
+}
+
+// RevertFields versiyadagi tahrirlanadigan maydonlar. username va avatar
+// qaytarilmaydi, ular o'z qoidalari bilan alohida o'zgartiriladi.
+func RevertFields(v *pb.ProfileVersion) map[string]string {
+	return map[string]string{
+		FieldFullname:    v.Fullname,
+		FieldDateOfBirth: v.DateOfBirth,
+		FieldPhoneNumber: v.PhoneNumber,
+		FieldAddress:     v.Address,
+		FieldLocale:      v.Locale,
+		FieldTimezone:    v.Timezone,
+	}
+}
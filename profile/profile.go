@@ -0,0 +1,107 @@
+package profile
+
+import (
+	"auth-service/auth/identifier"
+	"auth-service/i18n"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Tahrirlanadigan profil maydonlari. Nomlar GetUserProfileResponse JSON
+// kalitlari bilan bir xil.
+const (
+	FieldFullname    = "fullname"
+	FieldDateOfBirth = "date_of_birth"
+	FieldPhoneNumber = "phone_number"
+	FieldAddress     = "address"
+	FieldLocale      = "locale"
+	FieldTimezone    = "timezone"
+)
+
+var Fields = []string{FieldFullname, FieldDateOfBirth, FieldPhoneNumber, FieldAddress, FieldLocale, FieldTimezone}
+
+// replaceFields bo'sh update_mask almashtiradigan maydonlar. locale va
+// timezone ni eski klientlar bilmaydi, ular faqat mask yoki patch bilan
+// o'zgaradi.
+var replaceFields = []string{FieldFullname, FieldDateOfBirth, FieldPhoneNumber, FieldAddress}
+
+var ErrUnknownField = errors.New("unknown profile field")
+
+// pathAliases FieldMask yo'llari proto nomlari bilan keladi
+var pathAliases = map[string]string{
+	"fullName":      FieldFullname,
+	"full_name":     FieldFullname,
+	"fullname":      FieldFullname,
+	"date_of_birth": FieldDateOfBirth,
+	"phone_number":  FieldPhoneNumber,
+	"address":       FieldAddress,
+	"locale":        FieldLocale,
+	"timezone":      FieldTimezone,
+}
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+
+// FieldFromPath FieldMask yo'lini maydon nomiga aylantiradi
+func FieldFromPath(path string) (string, error) {
+	field, ok := pathAliases[strings.TrimSpace(path)]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownField, path)
+	}
+	return field, nil
+}
+
+// Normalize maydon qiymatini tekshiradi va bazaga yoziladigan ko'rinishda
+// qaytaradi. Bo'sh qiymat maydonni tozalaydi (ism bundan mustasno).
+func Normalize(field, value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	switch field {
+	case FieldFullname:
+		if value == "" {
+			return "", errors.New("fullname cannot be empty")
+		}
+		if utf8.RuneCountInString(value) > 200 {
+			return "", errors.New("fullname must be at most 200 characters")
+		}
+	case FieldDateOfBirth:
+		if value == "" {
+			return "", nil
+		}
+		dob, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return "", errors.New("date_of_birth must be YYYY-MM-DD")
+		}
+		if dob.Year() < 1900 || dob.After(time.Now()) {
+			return "", errors.New("date_of_birth must be between 1900 and today")
+		}
+	case FieldPhoneNumber:
+		if value == "" {
+			return "", nil
+		}
+		value = identifier.NormalizePhone(value)
+		if !phonePattern.MatchString(value) {
+			return "", errors.New("phone_number must be 7-15 digits, optionally starting with +")
+		}
+	case FieldAddress:
+		if utf8.RuneCountInString(value) > 255 {
+			return "", errors.New("address must be at most 255 characters")
+		}
+	case FieldLocale:
+		value = strings.ToLower(value)
+		if value != "" && !i18n.Supported(value) {
+			return "", errors.New("locale must be one of uz, ru, en")
+		}
+	case FieldTimezone:
+		if value != "" && !i18n.ValidTimezone(value) {
+			return "", errors.New("timezone must be an IANA time zone such as Asia/Tashkent")
+		}
+	default:
+		return "", fmt.Errorf("%w %q", ErrUnknownField, field)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,66 @@
+package profile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldFromPath(t *testing.T) {
+	field, err := FieldFromPath("fullName")
+	require.NoError(t, err)
+	assert.Equal(t, FieldFullname, field)
+
+	_, err = FieldFromPath("username")
+	assert.ErrorIs(t, err, ErrUnknownField)
+}
+
+func TestNormalize(t *testing.T) {
+	v, err := Normalize(FieldPhoneNumber, " +998 93 995-57-26 ")
+	require.NoError(t, err)
+	assert.Equal(t, "+998939955726", v)
+
+	v, err = Normalize(FieldDateOfBirth, "")
+	require.NoError(t, err)
+	assert.Equal(t, "", v)
+
+	v, err = Normalize(FieldLocale, "RU")
+	require.NoError(t, err)
+	assert.Equal(t, "ru", v)
+
+	v, err = Normalize(FieldTimezone, "Asia/Tashkent")
+	require.NoError(t, err)
+	assert.Equal(t, "Asia/Tashkent", v)
+
+	for _, c := range []struct{ field, value string }{
+		{FieldFullname, "  "},
+		{FieldFullname, strings.Repeat("a", 201)},
+		{FieldDateOfBirth, "20-11-2004"},
+		{FieldDateOfBirth, "2999-01-01"},
+		{FieldPhoneNumber, "call me"},
+		{FieldAddress, strings.Repeat("a", 256)},
+		{FieldLocale, "fr"},
+		{FieldTimezone, "Mars/Olympus"},
+		{"username", "ali"},
+	} {
+		_, err := Normalize(c.field, c.value)
+		assert.Error(t, err, c.field)
+	}
+}
+
+func TestFromMergePatch(t *testing.T) {
+	fields, err := FromMergePatch([]byte(`{"address": "Tashkent", "date_of_birth": null}`))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{FieldAddress: "Tashkent", FieldDateOfBirth: ""}, fields)
+
+	_, err = FromMergePatch([]byte(`{"username": "ali"}`))
+	assert.ErrorIs(t, err, ErrUnknownField)
+
+	_, err = FromMergePatch([]byte(`{"address": 5}`))
+	assert.Error(t, err)
+
+	_, err = FromMergePatch([]byte(`[]`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package profile
+
+import (
+	pb "auth-service/generated/auth_service"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// FromRequest update_mask dagi (bo'sh bo'lsa replaceFields) maydonlarni
+// tekshirib qaytaradi
+func FromRequest(in *pb.UpdateUserProfileRequest) (map[string]string, error) {
+	values := map[string]string{
+		FieldFullname:    in.FullName,
+		FieldDateOfBirth: in.DateOfBirth,
+		FieldPhoneNumber: in.PhoneNumber,
+		FieldAddress:     in.Address,
+		FieldLocale:      in.Locale,
+		FieldTimezone:    in.Timezone,
+	}
+
+	paths := replaceFields
+	if mask := in.GetUpdateMask(); mask != nil && len(mask.Paths) > 0 {
+		paths = mask.Paths
+	}
+
+	fields := map[string]string{}
+	for _, path := range paths {
+		field, err := FieldFromPath(path)
+		if err != nil {
+			return nil, err
+		}
+		if fields[field], err = Normalize(field, values[field]); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+// FromMergePatch RFC 7396 JSON merge patch ni o'qiydi: kalit yo'q bo'lsa
+// maydon o'zgarmaydi, null bo'lsa tozalanadi
+func FromMergePatch(body []byte) (map[string]string, error) {
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		return nil, errors.New("body must be a JSON object")
+	}
+
+	fields := map[string]string{}
+	for key, raw := range patch {
+		if !slices.Contains(Fields, key) {
+			return nil, fmt.Errorf("%w %q", ErrUnknownField, key)
+		}
+
+		var value *string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return nil, fmt.Errorf("%s must be a string or null", key)
+		}
+
+		var err error
+		if fields[key], err = Normalize(key, deref(value)); err != nil {
+			return nil, err
+		}
+	}
+	return fields, nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	state     State
+	expiresAt time.Time
+}
+
+// MemoryStore bitta instance uchun holatni xotirada saqlaydi
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*entry)}
+}
+
+func (m *MemoryStore) Update(ctx context.Context, key string, ttl time.Duration, fn func(State) (State, Result)) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.lastSweep) > time.Minute {
+		for k, e := range m.entries {
+			if now.After(e.expiresAt) {
+				delete(m.entries, k)
+			}
+		}
+		m.lastSweep = now
+	}
+
+	e, ok := m.entries[key]
+	if !ok {
+		e = &entry{}
+		m.entries[key] = e
+	}
+
+	state, res := fn(e.state)
+	e.state = state
+	e.expiresAt = now.Add(ttl)
+
+	return res, nil
+}
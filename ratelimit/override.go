@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Override kod ichidagi qoidaning konfiguratsiyadan berilgan qiymatlari.
+// Bo'sh Algorithm qoidadagi algoritmni saqlaydi.
+type Override struct {
+	Algorithm Algorithm
+	Limit     int
+	Window    time.Duration
+}
+
+// ParseOverrides RATE_LIMITS qiymatini o'qiydi:
+// "http_login=20/1m,grpc_register=sliding_window:3/1h"
+func ParseOverrides(spec string) (map[string]Override, error) {
+	overrides := map[string]Override{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("rate limit %q: expected name=[algorithm:]limit/window", item)
+		}
+
+		var o Override
+		if algorithm, rest, ok := strings.Cut(value, ":"); ok {
+			o.Algorithm = Algorithm(strings.TrimSpace(algorithm))
+			if o.Algorithm != TokenBucket && o.Algorithm != SlidingWindow {
+				return nil, fmt.Errorf("rate limit %q: unknown algorithm %q", item, o.Algorithm)
+			}
+			value = rest
+		}
+
+		limit, window, ok := strings.Cut(value, "/")
+		if !ok {
+			return nil, fmt.Errorf("rate limit %q: expected limit/window", item)
+		}
+		var err error
+		if o.Limit, err = strconv.Atoi(strings.TrimSpace(limit)); err != nil || o.Limit <= 0 {
+			return nil, fmt.Errorf("rate limit %q: limit must be a positive number", item)
+		}
+		if o.Window, err = time.ParseDuration(strings.TrimSpace(window)); err != nil || o.Window <= 0 {
+			return nil, fmt.Errorf("rate limit %q: window must be a duration such as 1m or 24h", item)
+		}
+
+		overrides[strings.TrimSpace(name)] = o
+	}
+	return overrides, nil
+}
+
+// apply rule.Name uchun override bo'lsa uni qo'llaydi
+func (o Override) apply(rule Rule) Rule {
+	if o.Algorithm != "" {
+		rule.Algorithm = o.Algorithm
+	}
+	rule.Limit = o.Limit
+	rule.Window = o.Window
+	return rule
+}
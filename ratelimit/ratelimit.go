@@ -0,0 +1,174 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+type Algorithm string
+
+const (
+	TokenBucket   Algorithm = "token_bucket"
+	SlidingWindow Algorithm = "sliding_window"
+)
+
+// KeyType qaysi belgi bo'yicha so'rovlar sanalishini aniqlaydi
+type KeyType string
+
+const (
+	KeyIP     KeyType = "ip"
+	KeyUserID KeyType = "user_id"
+	// KeyClientID autentifikatsiya qilingan API kalit yoki foydalanuvchi
+	KeyClientID KeyType = "client_id"
+)
+
+type Rule struct {
+	Name      string
+	Algorithm Algorithm
+	Limit     int
+	Window    time.Duration
+	Key       KeyType
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAt    time.Time
+}
+
+// State bitta kalit uchun saqlanadigan holat. Token bucket Tokens va
+// UpdatedAt dan, sliding window esa WindowStart, Count va PrevCount dan
+// foydalanadi.
+type State struct {
+	Tokens      float64
+	WindowStart time.Time
+	Count       int64
+	PrevCount   int64
+	UpdatedAt   time.Time
+}
+
+// Store kalit bo'yicha holatni qulflab o'qiydi, fn orqali yangilaydi va
+// saqlaydi. ttl o'tgandan keyin holatni o'chirib yuborish mumkin.
+type Store interface {
+	Update(ctx context.Context, key string, ttl time.Duration, fn func(State) (State, Result)) (Result, error)
+}
+
+type Limiter struct {
+	store     Store
+	overrides map[string]Override
+	now       func() time.Time
+}
+
+func NewLimiter(store Store) *Limiter {
+	return &Limiter{store: store, now: time.Now}
+}
+
+// WithOverrides kod ichidagi qoidalarni nomi bo'yicha konfiguratsiya
+// qiymatlari bilan almashtiradi. Override berilmagan qoidalar o'zgarmaydi.
+func (l *Limiter) WithOverrides(overrides map[string]Override) *Limiter {
+	l.overrides = overrides
+	return l
+}
+
+func (l *Limiter) Allow(ctx context.Context, rule Rule, key string) (Result, error) {
+	if o, ok := l.overrides[rule.Name]; ok {
+		rule = o.apply(rule)
+	}
+
+	now := l.now()
+	return l.store.Update(ctx, "rl:"+rule.Name+":"+key, 2*rule.Window, func(s State) (State, Result) {
+		return apply(rule, s, now)
+	})
+}
+
+// RetryAfterSeconds Retry-After uchun kamida 1 soniya qaytaradi
+func RetryAfterSeconds(res Result) int {
+	return max(1, int(math.Ceil(res.RetryAfter.Seconds())))
+}
+
+func apply(rule Rule, s State, now time.Time) (State, Result) {
+	if rule.Algorithm == SlidingWindow {
+		return slidingWindow(rule, s, now)
+	}
+	return tokenBucket(rule, s, now)
+}
+
+func tokenBucket(rule Rule, s State, now time.Time) (State, Result) {
+	capacity := float64(rule.Limit)
+	rate := capacity / rule.Window.Seconds()
+
+	if s.UpdatedAt.IsZero() {
+		s.Tokens = capacity
+	} else if elapsed := now.Sub(s.UpdatedAt).Seconds(); elapsed > 0 {
+		s.Tokens = math.Min(capacity, s.Tokens+elapsed*rate)
+	}
+	s.UpdatedAt = now
+
+	res := Result{Limit: rule.Limit}
+	if s.Tokens >= 1 {
+		s.Tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = seconds((1 - s.Tokens) / rate)
+	}
+	res.Remaining = int(math.Floor(s.Tokens))
+	res.ResetAt = now.Add(seconds((capacity - s.Tokens) / rate))
+
+	return s, res
+}
+
+func slidingWindow(rule Rule, s State, now time.Time) (State, Result) {
+	start := now.Truncate(rule.Window)
+	if !s.WindowStart.Equal(start) {
+		if s.WindowStart.Equal(start.Add(-rule.Window)) {
+			s.PrevCount = s.Count
+		} else {
+			s.PrevCount = 0
+		}
+		s.Count = 0
+		s.WindowStart = start
+	}
+	s.UpdatedAt = now
+
+	elapsed := float64(now.Sub(start)) / float64(rule.Window)
+	estimated := float64(s.PrevCount)*(1-elapsed) + float64(s.Count)
+
+	res := Result{Limit: rule.Limit, ResetAt: start.Add(rule.Window)}
+	if estimated+1 <= float64(rule.Limit) {
+		s.Count++
+		estimated++
+		res.Allowed = true
+	} else {
+		res.RetryAfter = slidingRetryAfter(rule, s, elapsed)
+	}
+	res.Remaining = max(0, rule.Limit-int(math.Ceil(estimated)))
+
+	return s, res
+}
+
+// slidingRetryAfter oldingi oyna ulushi kamayib, yana bitta so'rovga joy
+// ochiladigan vaqtni hisoblaydi.
+func slidingRetryAfter(rule Rule, s State, elapsed float64) time.Duration {
+	free := float64(rule.Limit - 1 - int(s.Count))
+	if free >= 0 && s.PrevCount > 0 {
+		need := 1 - free/float64(s.PrevCount)
+		return seconds((need - elapsed) * rule.Window.Seconds())
+	}
+
+	// Joriy oyna to'lgan: keyingi oynada joriy son oldingi songa aylanadi
+	next := (1 - elapsed) * rule.Window.Seconds()
+	if s.Count > 0 {
+		next += (1 - float64(rule.Limit-1)/float64(s.Count)) * rule.Window.Seconds()
+	}
+	return seconds(next)
+}
+
+func seconds(s float64) time.Duration {
+	if s < 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(NewMemoryStore())
+	limiter.now = func() time.Time { return now }
+
+	rule := Rule{Name: "login", Algorithm: TokenBucket, Limit: 3, Window: time.Minute, Key: KeyIP}
+
+	for i := 0; i < 3; i++ {
+		res, err := limiter.Allow(context.Background(), rule, "1.1.1.1")
+		assert.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 2-i, res.Remaining)
+	}
+
+	res, _ := limiter.Allow(context.Background(), rule, "1.1.1.1")
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 20*time.Second, res.RetryAfter)
+
+	res, _ = limiter.Allow(context.Background(), rule, "2.2.2.2")
+	assert.True(t, res.Allowed)
+
+	now = now.Add(20 * time.Second)
+	res, _ = limiter.Allow(context.Background(), rule, "1.1.1.1")
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+}
+
+func TestSlidingWindow(t *testing.T) {
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(NewMemoryStore())
+	limiter.now = func() time.Time { return now }
+
+	rule := Rule{Name: "register", Algorithm: SlidingWindow, Limit: 4, Window: time.Minute, Key: KeyIP}
+
+	for i := 0; i < 4; i++ {
+		res, _ := limiter.Allow(context.Background(), rule, "1.1.1.1")
+		assert.True(t, res.Allowed)
+	}
+
+	res, _ := limiter.Allow(context.Background(), rule, "1.1.1.1")
+	assert.False(t, res.Allowed)
+	assert.Equal(t, now.Add(time.Minute), res.ResetAt)
+
+	// Keyingi oynaning yarmida oldingi 4 ta so'rov 2 ta deb hisoblanadi
+	now = now.Add(90 * time.Second)
+	for i := 0; i < 2; i++ {
+		res, _ = limiter.Allow(context.Background(), rule, "1.1.1.1")
+		assert.True(t, res.Allowed)
+	}
+
+	res, _ = limiter.Allow(context.Background(), rule, "1.1.1.1")
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 15*time.Second, res.RetryAfter)
+}
+
+func TestOverrides(t *testing.T) {
+	overrides, err := ParseOverrides(" http_login=2/1m, grpc_register=token_bucket:1/1h ,")
+	assert.NoError(t, err)
+	assert.Equal(t, Override{Limit: 2, Window: time.Minute}, overrides["http_login"])
+	assert.Equal(t, Override{Algorithm: TokenBucket, Limit: 1, Window: time.Hour}, overrides["grpc_register"])
+
+	for _, spec := range []string{"http_login", "http_login=0/1m", "http_login=5/1x", "http_login=leaky:5/1m"} {
+		_, err := ParseOverrides(spec)
+		assert.Error(t, err, spec)
+	}
+
+	limiter := NewLimiter(NewMemoryStore()).WithOverrides(overrides)
+	rule := Rule{Name: "http_login", Algorithm: TokenBucket, Limit: 10, Window: time.Minute, Key: KeyIP}
+
+	res, _ := limiter.Allow(context.Background(), rule, "1.1.1.1")
+	assert.Equal(t, 2, res.Limit)
+	limiter.Allow(context.Background(), rule, "1.1.1.1")
+	res, _ = limiter.Allow(context.Background(), rule, "1.1.1.1")
+	assert.False(t, res.Allowed)
+}
@@ -0,0 +1,50 @@
+package reservation
+
+import (
+	"auth-service/config"
+	rpb "auth-service/generated/reservation_service"
+	"auth-service/logs"
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Restaurants restoran ma'lumotlari reservation-service da saqlanadi
+type Restaurants interface {
+	// Name restoran nomi. Restoran topilmasa yoki servis sozlanmagan bo'lsa bo'sh satr.
+	Name(ctx context.Context, restaurantId string) (string, error)
+}
+
+// New RESERVATION_SERVICE_ADDR bo'sh yoki noto'g'ri bo'lsa nomlarsiz
+// ishlaydi. Ulanish birinchi so'rovda ochiladi.
+func New(cfg config.Config) Restaurants {
+	if cfg.RESERVATION_SERVICE_ADDR == "" {
+		return noop{}
+	}
+
+	conn, err := grpc.NewClient(cfg.RESERVATION_SERVICE_ADDR, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		logs.Logger.Error("Invalid RESERVATION_SERVICE_ADDR", "error", err.Error())
+		return noop{}
+	}
+	return &Client{Reservations: rpb.NewReservationServiceClient(conn)}
+}
+
+type Client struct {
+	Reservations rpb.ReservationServiceClient
+}
+
+func (c *Client) Name(ctx context.Context, restaurantId string) (string, error) {
+	resp, err := c.Reservations.GetRestaurant(ctx, &rpb.GetRestaurantRequest{Id: restaurantId})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetRestaurant().GetName(), nil
+}
+
+type noop struct{}
+
+func (noop) Name(context.Context, string) (string, error) {
+	return "", nil
+}
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/apikey"
+	"auth-service/auth/lifecycle"
+	"auth-service/auth/principal"
+	"auth-service/auth/token"
+	"auth-service/i18n"
+	"auth-service/logs"
+	"auth-service/ratelimit"
+	"auth-service/storage/postgres"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimitInterceptor rules dagi gRPC metodlarni cheklaydi. Limitdan oshganda
+// RESOURCE_EXHAUSTED va retry-after header qaytaradi.
+func RateLimitInterceptor(limiter *ratelimit.Limiter, rules map[string]ratelimit.Rule) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		rule, ok := rules[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if err := allow(ctx, limiter, rule, rateLimitKey(ctx, rule.Key), true); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// PeerRateLimitInterceptor AuthInterceptor dan oldin har bir chaqiruvni
+// ulanish manzili bo'yicha sanaydi, shunda noto'g'ri token yoki API kalit
+// bilan taxmin qilish ham cheklanadi
+func PeerRateLimitInterceptor(limiter *ratelimit.Limiter, rule ratelimit.Rule) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := allow(ctx, limiter, rule, "ip:"+peerIP(ctx), false); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// allow limitdan oshganda RESOURCE_EXHAUSTED qaytaradi. headers false bo'lsa
+// x-ratelimit-* headerlari faqat rad etilganda yoziladi, metod qoidasi
+// headerlari bilan aralashmasligi uchun.
+func allow(ctx context.Context, limiter *ratelimit.Limiter, rule ratelimit.Rule, key string, headers bool) error {
+	res, err := limiter.Allow(ctx, rule, key)
+	if err != nil {
+		logs.Logger.Error("Rate limit store error", "rule", rule.Name, "error", err.Error())
+		return nil
+	}
+
+	md := metadata.Pairs(
+		"x-ratelimit-limit", strconv.Itoa(res.Limit),
+		"x-ratelimit-remaining", strconv.Itoa(res.Remaining),
+		"x-ratelimit-reset", strconv.FormatInt(res.ResetAt.Unix(), 10),
+	)
+
+	if !res.Allowed {
+		md.Set("retry-after", strconv.Itoa(ratelimit.RetryAfterSeconds(res)))
+		grpc.SetHeader(ctx, md)
+		return status.Error(codes.ResourceExhausted, tr(ctx, "too many requests, retry after %d seconds", ratelimit.RetryAfterSeconds(res)))
+	}
+
+	if headers {
+		grpc.SetHeader(ctx, md)
+	}
+	return nil
+}
+
+// rateLimitKey AuthInterceptor qo'ygan principal bo'yicha kalit beradi,
+// principal bo'lmasa IP manzilga qaytadi
+func rateLimitKey(ctx context.Context, key ratelimit.KeyType) string {
+	if p, ok := principal.FromContext(ctx); ok {
+		switch key {
+		case ratelimit.KeyUserID:
+			if p.UserId != "" {
+				return "user:" + p.UserId
+			}
+		case ratelimit.KeyClientID:
+			if p.IsAPIKey() {
+				return "apikey:" + p.APIKeyId
+			}
+			return "user:" + p.UserId
+		}
+	}
+
+	return "ip:" + peerIP(ctx)
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// AuthInterceptor authorization (Bearer token) yoki x-api-key metadata
+// sini tekshirib, principal.Principal ni kontekstga qo'yadi. Credential
+// berilmagan so'rovlar o'tkazib yuboriladi, noto'g'risi UNAUTHENTICATED.
+func AuthInterceptor(sessions *postgres.SessionRepo, apiKeys *postgres.APIKeyRepo) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		if v := md.Get(strings.ToLower(apikey.Header)); len(v) > 0 && v[0] != "" {
+			stored, err := apiKeys.Authenticate(apikey.Hash(v[0]))
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, status.Error(codes.Unauthenticated, tr(ctx, "invalid API key"))
+			}
+			if err != nil {
+				logs.Logger.Error("Error checking API key", "error", err.Error())
+				return nil, status.Error(codes.Internal, tr(ctx, "failed to check API key"))
+			}
+
+			return handler(principal.NewContext(ctx, &principal.Principal{
+				APIKeyId:     stored.Id,
+				RestaurantId: stored.RestaurantId,
+				Permissions:  stored.Permissions,
+			}), req)
+		}
+
+		v := md.Get("authorization")
+		if len(v) == 0 || v[0] == "" {
+			return handler(ctx, req)
+		}
+
+		claims, err := token.ExtractClaim(v[0])
+		if err != nil || claims.ExpiresAt < time.Now().Unix() {
+			return nil, status.Error(codes.Unauthenticated, tr(ctx, "invalid token"))
+		}
+
+		accountStatus, err := sessions.Touch(claims.SessionId)
+		if err != nil {
+			logs.Logger.Error("Error checking session", "error", err.Error())
+			return nil, status.Error(codes.Internal, tr(ctx, "failed to check session"))
+		}
+		if accountStatus == "" {
+			return nil, status.Error(codes.Unauthenticated, tr(ctx, "session has been revoked"))
+		}
+		if accountStatus != lifecycle.StatusActive {
+			return nil, accountStatusError(ctx, accountStatus)
+		}
+
+		p := &principal.Principal{
+			UserId:    claims.UserId,
+			Username:  claims.Username,
+			Email:     claims.Email,
+			SessionId: claims.SessionId,
+			Role:      claims.Role,
+			Locale:    claims.Locale,
+			Timezone:  claims.Timezone,
+		}
+		if claims.Act != nil {
+			p.ActorId = claims.Act.Sub
+		}
+
+		return handler(principal.NewContext(ctx, p), req)
+	}
+}
+
+// impersonationDenied support tokeni bilan chaqirib bo'lmaydigan metodlar,
+// HTTP dagi DenyImpersonation bilan bir xil
+var impersonationDenied = map[string]bool{
+	"/auth_service.AuthService/RevokeSession":       true,
+	"/auth_service.AuthService/RevokeOtherSessions": true,
+	"/auth_service.AuthService/LogoutUser":          true,
+	"/auth_service.AuthService/ChangeUsername":      true,
+	"/auth_service.AuthService/RegisterPushDevice":  true,
+	"/auth_service.AuthService/RefreshPushDevice":   true,
+}
+
+// ImpersonationInterceptor support tokeni bilan kelgan har bir chaqiruvni
+// audit jurnaliga yozadi va impersonationDenied metodlarini taqiqlaydi.
+// AuthInterceptor dan keyin turishi kerak.
+func ImpersonationInterceptor(auditRepo *postgres.AuditRepo) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		p, ok := principal.FromContext(ctx)
+		if !ok || p.ActorId == "" {
+			return handler(ctx, req)
+		}
+
+		var resp interface{}
+		var err error
+		if impersonationDenied[info.FullMethod] {
+			err = status.Error(codes.PermissionDenied, tr(ctx, "this action is not allowed while impersonating a user"))
+		} else {
+			resp, err = handler(ctx, req)
+		}
+
+		after, _ := json.Marshal(map[string]string{
+			"method": info.FullMethod,
+			"status": status.Code(err).String(),
+		})
+		entry := &audit.Entry{
+			ActorId:    p.ActorId,
+			Action:     audit.ActionImpersonationAction,
+			TargetType: "user",
+			TargetId:   p.UserId,
+			After:      string(after),
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		if v := md.Get("x-request-id"); len(v) > 0 {
+			entry.RequestId = v[0]
+		}
+		if auditErr := auditRepo.Append(entry); auditErr != nil {
+			logs.Logger.Error("Error writing impersonation audit log", "error", auditErr.Error())
+		}
+
+		return resp, err
+	}
+}
+
+// accountStatusError faol bo'lmagan hisob uchun PERMISSION_DENIED qaytaradi.
+// Aniq sabab ErrorInfo.Reason da (masalan account_suspended).
+func accountStatusError(ctx context.Context, accountStatus string) error {
+	st, err := status.New(codes.PermissionDenied, tr(ctx, lifecycle.Message(accountStatus))).WithDetails(&errdetails.ErrorInfo{
+		Reason: lifecycle.Code(accountStatus),
+		Domain: "auth-service",
+	})
+	if err != nil {
+		return status.Error(codes.PermissionDenied, lifecycle.Code(accountStatus))
+	}
+	return st.Err()
+}
+
+// tr xabarni accept-language metadata tiliga tarjima qiladi
+func tr(ctx context.Context, msg string, args ...interface{}) string {
+	var header string
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get("accept-language"); len(v) > 0 {
+		header = v[0]
+	}
+	return i18n.T(i18n.Resolve(i18n.Negotiate(header)), msg, args...)
+}
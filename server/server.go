@@ -4,16 +4,42 @@ import (
 	"auth-service/config"
 	pb "auth-service/generated/auth_service"
 	"auth-service/logs"
+	"auth-service/ratelimit"
 	"auth-service/service"
 	"auth-service/storage/postgres"
 	"database/sql"
 	"log"
 	"net"
+	"time"
 
 	"google.golang.org/grpc"
 )
 
-func ServerRun(db *sql.DB) {
+// rateLimits har bir gRPC metod uchun limit qoidalari
+var rateLimits = map[string]ratelimit.Rule{
+	"/auth_service.AuthService/RegisterUser": {
+		Name: "grpc_register", Algorithm: ratelimit.SlidingWindow, Limit: 5, Window: time.Hour, Key: ratelimit.KeyIP,
+	},
+	"/auth_service.AuthService/LoginUser": {
+		Name: "grpc_login", Algorithm: ratelimit.TokenBucket, Limit: 10, Window: time.Minute, Key: ratelimit.KeyIP,
+	},
+	"/auth_service.AuthService/GetUserProfile": {
+		Name: "grpc_get_user_profile", Algorithm: ratelimit.TokenBucket, Limit: 100, Window: time.Minute, Key: ratelimit.KeyClientID,
+	},
+	"/auth_service.AuthService/UpdateUserProfile": {
+		Name: "grpc_update_user_profile", Algorithm: ratelimit.SlidingWindow, Limit: 30, Window: time.Minute, Key: ratelimit.KeyUserID,
+	},
+	"/auth_service.AuthService/LogoutUser": {
+		Name: "grpc_logout", Algorithm: ratelimit.TokenBucket, Limit: 30, Window: time.Minute, Key: ratelimit.KeyUserID,
+	},
+}
+
+// peerRateLimit autentifikatsiyadan oldin ulanish manzili bo'yicha umumiy limit
+var peerRateLimit = ratelimit.Rule{
+	Name: "grpc_peer", Algorithm: ratelimit.TokenBucket, Limit: 600, Window: time.Minute, Key: ratelimit.KeyIP,
+}
+
+func ServerRun(db *sql.DB, limiter *ratelimit.Limiter) {
 	logs.InitLogger()
 	cfg := config.Load()
 	listener, err := net.Listen("tcp", cfg.GRPC_PORT)
@@ -22,8 +48,15 @@ func ServerRun(db *sql.DB) {
 		log.Fatal(err)
 	}
 
-	s := grpc.NewServer()
-	service := service.NewAuthService(postgres.NewUserRepo(db))
+	// Metod limitlari kaliti principal dan olinadi, shuning uchun ular
+	// AuthInterceptor dan keyin. Undan oldin faqat IP bo'yicha limit.
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		PeerRateLimitInterceptor(limiter, peerRateLimit),
+		AuthInterceptor(postgres.NewSessionRepo(db), postgres.NewAPIKeyRepo(db)),
+		ImpersonationInterceptor(postgres.NewAuditRepo(db)),
+		RateLimitInterceptor(limiter, rateLimits),
+	))
+	service := service.NewAuthService(db, logs.Logger)
 
 	pb.RegisterAuthServiceServer(s, service)
 
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"auth-service/auth/principal"
+	pb "auth-service/generated/auth_service"
+	"auth-service/models"
+	"auth-service/storage/postgres"
+	"context"
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requireAdmin AuthInterceptor qo'ygan principal admin ekanini tekshiradi.
+// Support tokenlari admin metodlariga kira olmaydi.
+func requireAdmin(ctx context.Context) error {
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, tr(ctx, "authorization is required"))
+	}
+	if !isAdmin(p) {
+		return status.Error(codes.PermissionDenied, tr(ctx, "admin access required"))
+	}
+	return nil
+}
+
+// isAdmin impersonatsiyasiz admin tokeni
+func isAdmin(p *principal.Principal) bool {
+	return !p.IsAPIKey() && p.Role == models.RoleAdmin && p.ActorId == ""
+}
+
+// requireUser so'rov foydalanuvchi tokeni bilan kelganini tekshiradi.
+// API kalitlarida foydalanuvchi yo'q.
+func requireUser(ctx context.Context) (*principal.Principal, error) {
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, tr(ctx, "authorization is required"))
+	}
+	if p.IsAPIKey() {
+		return nil, status.Error(codes.PermissionDenied, tr(ctx, "a user token is required"))
+	}
+	return p, nil
+}
+
+// requireSelfOrAdmin so'rov shu foydalanuvchining o'zidan yoki admindan
+// kelganini tekshiradi
+func requireSelfOrAdmin(ctx context.Context, userId string) (*principal.Principal, error) {
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, tr(ctx, "authorization is required"))
+	}
+	if p.IsAPIKey() || (p.UserId != userId && p.Role != models.RoleAdmin) {
+		return nil, status.Error(codes.PermissionDenied, tr(ctx, "access to this user is not allowed"))
+	}
+	return p, nil
+}
+
+func (a *AuthService) SearchUsers(ctx context.Context, in *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	a.Logger.Info("gRPC method SearchUsers")
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := a.User.SearchUsers(in)
+	if errors.Is(err, postgres.ErrInvalidFilter) {
+		return nil, status.Error(codes.InvalidArgument, tr(ctx, err.Error()))
+	}
+	if err != nil {
+		a.Logger.Error("Error searching users:", "error", err.Error())
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (a *AuthService) GetUserDetails(ctx context.Context, in *pb.GetUserDetailsRequest) (*pb.UserDetails, error) {
+	a.Logger.Info("gRPC method GetUserDetails")
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	user, err := a.User.GetUserSummary(in.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "user not found"))
+	}
+	if err != nil {
+		a.Logger.Error("Error getting user:", "error", err.Error())
+		return nil, err
+	}
+
+	resp := &pb.UserDetails{User: user}
+	resp.Profile, err = a.User.GetUserProfileById(in.UserId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		a.Logger.Error("Error getting user profile:", "error", err.Error())
+		return nil, err
+	}
+
+	sessions, err := a.Session.ListSessions(&pb.ListSessionsRequest{UserId: in.UserId})
+	if err != nil {
+		a.Logger.Error("Error listing sessions:", "error", err.Error())
+		return nil, err
+	}
+	resp.Sessions = sessions.Sessions
+
+	resp.Restaurants, err = a.Restaurant.ListMemberships(in.UserId)
+	if err != nil {
+		a.Logger.Error("Error listing restaurant memberships:", "error", err.Error())
+		return nil, err
+	}
+
+	return resp, nil
+}
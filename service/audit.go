@@ -0,0 +1,72 @@
+package service
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/principal"
+	"auth-service/profile"
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// audit gRPC chaqiruvini audit jurnaliga yozadi. Actor AuthInterceptor
+// qo'ygan principal dan olinadi, impersonatsiyada support xodimi.
+func (a *AuthService) audit(ctx context.Context, action, targetType, targetId string, before, after interface{}) {
+	var actorId string
+	if p, ok := principal.FromContext(ctx); ok {
+		switch {
+		case p.IsAPIKey():
+			actorId = audit.APIKeyActor(p.APIKeyId)
+		case p.ActorId != "":
+			actorId = p.ActorId
+		default:
+			actorId = p.UserId
+		}
+	}
+
+	a.auditAs(ctx, actorId, action, targetType, targetId, before, after)
+}
+
+// profileChange profil tarixi uchun o'zgartiruvchini principal dan oladi.
+// Impersonatsiyada support xodimi, API kalit bo'lsa kalit yoziladi.
+func profileChange(ctx context.Context) profile.Change {
+	change := profile.Change{Channel: profile.ChannelGRPC}
+	if p, ok := principal.FromContext(ctx); ok {
+		switch {
+		case p.IsAPIKey():
+			change.ActorId = audit.APIKeyActor(p.APIKeyId)
+		case p.ActorId != "":
+			change.ActorId = p.ActorId
+		default:
+			change.ActorId = p.UserId
+		}
+	}
+	return change
+}
+
+// auditAs actor ni aniq ko'rsatib yozadi, masalan API kalit uchun
+func (a *AuthService) auditAs(ctx context.Context, actorId, action, targetType, targetId string, before, after interface{}) {
+	b, af, err := audit.Diff(before, after)
+	if err != nil {
+		a.Logger.Error("Error building audit diff", "error", err.Error())
+		return
+	}
+
+	entry := &audit.Entry{
+		ActorId:    actorId,
+		Action:     action,
+		TargetType: targetType,
+		TargetId:   targetId,
+		Before:     b,
+		After:      af,
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get("x-request-id"); len(v) > 0 {
+		entry.RequestId = v[0]
+	}
+
+	if err := a.Audit.Append(entry); err != nil {
+		a.Logger.Error("Error writing audit log", "action", action, "error", err.Error())
+	}
+}
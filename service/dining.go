@@ -0,0 +1,58 @@
+package service
+
+import (
+	"auth-service/audit"
+	"auth-service/dining"
+	pb "auth-service/generated/auth_service"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *AuthService) GetDiningPreferences(ctx context.Context, in *pb.GetDiningPreferencesRequest) (*pb.DiningPreferences, error) {
+	a.Logger.Info("gRPC method GetDiningPreferences")
+	if _, err := requireSelfOrAdmin(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+
+	resp, err := a.User.GetDiningPreferences(in.UserId)
+	if err != nil {
+		a.Logger.Error("Error getting dining preferences:", "error", err.Error())
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (a *AuthService) UpdateDiningPreferences(ctx context.Context, in *pb.DiningPreferences) (*pb.DiningPreferences, error) {
+	a.Logger.Info("gRPC method UpdateDiningPreferences")
+	if _, err := requireSelfOrAdmin(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := dining.Normalize(in); err != nil {
+		return nil, status.Error(codes.InvalidArgument, tr(ctx, err.Error()))
+	}
+
+	before, err := a.User.GetDiningPreferences(in.UserId)
+	if err != nil {
+		a.Logger.Error("Error getting dining preferences:", "error", err.Error())
+		return nil, err
+	}
+
+	if err := a.User.SetDiningPreferences(in); err != nil {
+		a.Logger.Error("Error updating dining preferences:", "error", err.Error())
+		return nil, err
+	}
+
+	resp, err := a.User.GetDiningPreferences(in.UserId)
+	if err != nil {
+		a.Logger.Error("Error getting dining preferences:", "error", err.Error())
+		return nil, err
+	}
+
+	a.audit(ctx, audit.ActionProfileUpdate, "user", in.UserId, before, resp)
+
+	return resp, nil
+}
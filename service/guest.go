@@ -0,0 +1,63 @@
+package service
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/apikey"
+	"auth-service/auth/principal"
+	pb "auth-service/generated/auth_service"
+	"context"
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetGuestProfile restoran xodimi yoki shu restoran API kaliti uchun dinerning
+// ulashishga rozi bo'lgan ma'lumotlari. Har bir muvaffaqiyatli so'rov auditga yoziladi.
+func (a *AuthService) GetGuestProfile(ctx context.Context, in *pb.GetGuestProfileRequest) (*pb.GuestProfile, error) {
+	a.Logger.Info("gRPC method GetGuestProfile")
+
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, tr(ctx, "authorization is required"))
+	}
+	if in.RestaurantId == "" || in.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, tr(ctx, "restaurant_id and user_id are required"))
+	}
+
+	// Impersonatsiyada support xodimi yoziladi
+	actorId := p.UserId
+	if p.ActorId != "" {
+		actorId = p.ActorId
+	}
+	if p.IsAPIKey() {
+		if p.RestaurantId != in.RestaurantId || !p.HasPermission(apikey.PermissionGuestsRead) {
+			return nil, status.Error(codes.PermissionDenied, tr(ctx, "API key cannot read guests of this restaurant"))
+		}
+		actorId = audit.APIKeyActor(p.APIKeyId)
+	} else {
+		role, err := a.Restaurant.MemberRole(in.RestaurantId, p.UserId)
+		if err != nil {
+			a.Logger.Error("Error checking restaurant membership:", "error", err.Error())
+			return nil, err
+		}
+		if role == "" {
+			return nil, status.Error(codes.PermissionDenied, tr(ctx, "restaurant membership required"))
+		}
+	}
+
+	guest, err := a.User.GetGuestProfile(in.RestaurantId, in.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "user not found"))
+	}
+	if err != nil {
+		a.Logger.Error("Error getting guest profile:", "error", err.Error())
+		return nil, err
+	}
+
+	a.auditAs(ctx, actorId, audit.ActionGuestProfileAccess, "user", in.UserId, nil,
+		map[string]interface{}{"restaurant_id": in.RestaurantId, "fields": guest.SharedFields})
+
+	return guest, nil
+}
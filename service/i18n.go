@@ -0,0 +1,24 @@
+package service
+
+import (
+	"auth-service/auth/principal"
+	"auth-service/i18n"
+	"context"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// tr xabarni chaqiruvchi tiliga tarjima qiladi: token dagi locale, bo'lmasa
+// accept-language metadata
+func tr(ctx context.Context, msg string, args ...interface{}) string {
+	var locale string
+	if p, ok := principal.FromContext(ctx); ok {
+		locale = p.Locale
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get("accept-language"); len(v) > 0 {
+		return i18n.T(i18n.Resolve(locale, i18n.Negotiate(v[0])), msg, args...)
+	}
+	return i18n.T(i18n.Resolve(locale), msg, args...)
+}
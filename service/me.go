@@ -0,0 +1,61 @@
+package service
+
+import (
+	pb "auth-service/generated/auth_service"
+	"context"
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetMyProfile token egasining profili
+func (a *AuthService) GetMyProfile(ctx context.Context, in *pb.GetMyProfileRequest) (*pb.GetUserProfileResponse, error) {
+	a.Logger.Info("gRPC method GetMyProfile")
+	p, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.User.GetUserProfileById(p.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "profile not found"))
+	}
+	if err != nil {
+		a.Logger.Error("Error getting user profile:", "error", err.Error())
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// UpdateMyProfile token egasining profilini yangilaydi, in.UserId e'tiborga olinmaydi
+func (a *AuthService) UpdateMyProfile(ctx context.Context, in *pb.UpdateUserProfileRequest) (*pb.UpdateUserProfileResponse, error) {
+	a.Logger.Info("gRPC method UpdateMyProfile")
+	p, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.updateProfile(ctx, p.UserId, in)
+}
+
+// Logout token sessiyasini yopadi
+func (a *AuthService) Logout(ctx context.Context, in *pb.LogoutSessionRequest) (*pb.LogoutResponse, error) {
+	a.Logger.Info("gRPC method Logout")
+	p, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.Session.RevokeSession(p.UserId, p.SessionId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		a.Logger.Error("Error revoking session:", "error", err.Error())
+		return nil, err
+	}
+
+	return &pb.LogoutResponse{
+		Message: tr(ctx, "Logged out successfully"),
+	}, nil
+}
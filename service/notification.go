@@ -0,0 +1,84 @@
+package service
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/apikey"
+	"auth-service/auth/principal"
+	pb "auth-service/generated/auth_service"
+	"auth-service/notify"
+	"context"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GetNotificationPreferences foydalanuvchining o'zi, admin yoki
+// notifications:read ruxsatli API kalit (bron xizmati) uchun
+func (a *AuthService) GetNotificationPreferences(ctx context.Context, in *pb.GetNotificationPreferencesRequest) (*pb.NotificationPreferences, error) {
+	a.Logger.Info("gRPC method GetNotificationPreferences")
+
+	if p, ok := principal.FromContext(ctx); ok && p.IsAPIKey() {
+		if !p.HasPermission(apikey.PermissionNotificationsRead) {
+			return nil, status.Error(codes.PermissionDenied, tr(ctx, "API key lacks permission %s", apikey.PermissionNotificationsRead))
+		}
+	} else if _, err := requireSelfOrAdmin(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+	if in.Category != "" && !slices.Contains(notify.Categories, in.Category) {
+		return nil, status.Error(codes.InvalidArgument, tr(ctx, "unknown notification category"))
+	}
+
+	resp, err := a.User.GetNotificationPreferences(in.UserId)
+	if err != nil {
+		a.Logger.Error("Error getting notification preferences:", "error", err.Error())
+		return nil, err
+	}
+
+	if in.Category != "" {
+		prefs := []*pb.NotificationPreference{}
+		for _, p := range resp.Preferences {
+			if p.Category == in.Category {
+				prefs = append(prefs, p)
+			}
+		}
+		resp.Preferences = prefs
+	}
+
+	return resp, nil
+}
+
+// UpdateNotificationPreferences faqat ro'yxatdagi kategoriya va kanallarni
+// o'zgartiradi
+func (a *AuthService) UpdateNotificationPreferences(ctx context.Context, in *pb.NotificationPreferences) (*pb.NotificationPreferences, error) {
+	a.Logger.Info("gRPC method UpdateNotificationPreferences")
+	if _, err := requireSelfOrAdmin(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+
+	prefs, err := notify.Normalize(in.Preferences)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, tr(ctx, err.Error()))
+	}
+
+	before, err := a.User.GetNotificationPreferences(in.UserId)
+	if err != nil {
+		a.Logger.Error("Error getting notification preferences:", "error", err.Error())
+		return nil, err
+	}
+
+	if err := a.User.SetNotificationPreferences(in.UserId, prefs); err != nil {
+		a.Logger.Error("Error updating notification preferences:", "error", err.Error())
+		return nil, err
+	}
+
+	resp, err := a.User.GetNotificationPreferences(in.UserId)
+	if err != nil {
+		a.Logger.Error("Error getting notification preferences:", "error", err.Error())
+		return nil, err
+	}
+
+	a.audit(ctx, audit.ActionProfileUpdate, "user", in.UserId, before, resp)
+
+	return resp, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"auth-service/audit"
+	pb "auth-service/generated/auth_service"
+	"auth-service/profile"
+	"auth-service/storage/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *AuthService) ListProfileHistory(ctx context.Context, in *pb.ListProfileHistoryRequest) (*pb.ListProfileHistoryResponse, error) {
+	a.Logger.Info("gRPC method ListProfileHistory")
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := a.User.ListProfileHistory(in)
+	if err != nil {
+		a.Logger.Error("Error listing profile history:", "error", err.Error())
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (a *AuthService) GetProfileAsOf(ctx context.Context, in *pb.GetProfileAsOfRequest) (*pb.ProfileVersion, error) {
+	a.Logger.Info("gRPC method GetProfileAsOf")
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := time.Parse(time.RFC3339, in.At); err != nil {
+		return nil, status.Error(codes.InvalidArgument, tr(ctx, "at must be an RFC3339 timestamp"))
+	}
+
+	resp, err := a.User.GetProfileAsOf(in.UserId, in.At)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "profile did not exist at that time"))
+	}
+	if err != nil {
+		a.Logger.Error("Error getting profile version:", "error", err.Error())
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RevertProfile tahrirlanadigan maydonlarni eski versiyadagidek qiladi.
+// Natija tarixga yangi versiya bo'lib yoziladi.
+func (a *AuthService) RevertProfile(ctx context.Context, in *pb.RevertProfileRequest) (*pb.UpdateUserProfileResponse, error) {
+	a.Logger.Info("gRPC method RevertProfile")
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if in.CurrentVersion <= 0 {
+		return nil, status.Error(codes.FailedPrecondition, tr(ctx, "current_version is required"))
+	}
+
+	target, err := a.User.GetProfileVersion(in.UserId, in.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "profile version not found"))
+	}
+	if err != nil {
+		a.Logger.Error("Error getting profile version:", "error", err.Error())
+		return nil, err
+	}
+
+	before, err := a.User.GetUserProfileById(in.UserId)
+	if err != nil {
+		a.Logger.Error("Error getting user profile:", "error", err.Error())
+		return nil, err
+	}
+
+	change := profileChange(ctx)
+	change.RevertedFrom = target.Version
+	version, err := a.User.UpdateProfileFields(in.UserId, profile.RevertFields(target), in.CurrentVersion, change)
+	if errors.Is(err, postgres.ErrVersionConflict) {
+		return nil, status.Error(codes.FailedPrecondition, tr(ctx, "profile was changed, current version is %d", version))
+	}
+	if err != nil {
+		a.Logger.Error("Error reverting user profile:", "error", err.Error())
+		return nil, err
+	}
+
+	after, err := a.User.GetUserProfileById(in.UserId)
+	if err != nil {
+		a.Logger.Error("Error getting user profile:", "error", err.Error())
+		return nil, err
+	}
+
+	a.audit(ctx, audit.ActionProfileUpdate, "user", in.UserId, before, after)
+
+	return &pb.UpdateUserProfileResponse{
+		Message: tr(ctx, "Profile reverted successfully"),
+		Version: version,
+	}, nil
+}
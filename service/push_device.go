@@ -0,0 +1,123 @@
+package service
+
+import (
+	"auth-service/auth/principal"
+	pb "auth-service/generated/auth_service"
+	"auth-service/notify"
+	"context"
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// requireDeviceOwner push qurilmalari token sessiyasiga bog'lanadi, shuning
+// uchun API kalit va support sessiyalari ishlatolmaydi
+func requireDeviceOwner(ctx context.Context) (*principal.Principal, error) {
+	p, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.ActorId != "" {
+		return nil, status.Error(codes.PermissionDenied, tr(ctx, "cannot manage push devices while impersonating"))
+	}
+	return p, nil
+}
+
+// RegisterPushDevice tokenni joriy sessiyaga bog'laydi
+func (a *AuthService) RegisterPushDevice(ctx context.Context, in *pb.RegisterPushDeviceRequest) (*pb.PushDevice, error) {
+	a.Logger.Info("gRPC method RegisterPushDevice")
+	p, err := requireDeviceOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, token, err := notify.NormalizePushToken(in.Provider, in.Token)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, tr(ctx, err.Error()))
+	}
+
+	resp, err := a.Session.RegisterPushDevice(p.UserId, p.SessionId, provider, token)
+	if err != nil {
+		a.Logger.Error("Error registering push device:", "error", err.Error())
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RefreshPushDevice provayder yangilagan tokenni yozadi
+func (a *AuthService) RefreshPushDevice(ctx context.Context, in *pb.RefreshPushDeviceRequest) (*pb.PushDevice, error) {
+	a.Logger.Info("gRPC method RefreshPushDevice")
+	p, err := requireDeviceOwner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := a.Session.GetPushDevice(p.UserId, in.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "push device not found"))
+	}
+	if err != nil {
+		a.Logger.Error("Error getting push device:", "error", err.Error())
+		return nil, err
+	}
+
+	_, token, err := notify.NormalizePushToken(device.Provider, in.Token)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, tr(ctx, err.Error()))
+	}
+
+	resp, err := a.Session.RefreshPushDevice(p.UserId, p.SessionId, in.Id, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "push device not found"))
+	}
+	if err != nil {
+		a.Logger.Error("Error refreshing push device:", "error", err.Error())
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// RemovePushDevice token egasining qurilmasini o'chiradi
+func (a *AuthService) RemovePushDevice(ctx context.Context, in *pb.RemovePushDeviceRequest) (*pb.RemovePushDeviceResponse, error) {
+	a.Logger.Info("gRPC method RemovePushDevice")
+	p, err := requireUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.Session.RemovePushDevice(p.UserId, in.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "push device not found"))
+	}
+	if err != nil {
+		a.Logger.Error("Error removing push device:", "error", err.Error())
+		return nil, err
+	}
+
+	return &pb.RemovePushDeviceResponse{
+		Message: tr(ctx, "Push device removed successfully"),
+	}, nil
+}
+
+// ListUserDevices faol sessiyalardagi push qurilmalar. Bildirishnoma worker
+// admin tokeni bilan chaqiradi.
+func (a *AuthService) ListUserDevices(ctx context.Context, in *pb.ListUserDevicesRequest) (*pb.ListUserDevicesResponse, error) {
+	a.Logger.Info("gRPC method ListUserDevices")
+	if _, err := requireSelfOrAdmin(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+
+	devices, err := a.Session.ListPushDevices(in.UserId, in.Provider)
+	if err != nil {
+		a.Logger.Error("Error listing push devices:", "error", err.Error())
+		return nil, err
+	}
+
+	return &pb.ListUserDevicesResponse{
+		Devices: devices,
+	}, nil
+}
@@ -1,25 +1,71 @@
 package service
 
 import (
+	"auth-service/audit"
+	"auth-service/auth/apikey"
+	"auth-service/auth/principal"
+	"auth-service/config"
 	pb "auth-service/generated/auth_service"
+	"auth-service/profile"
+	"auth-service/storage/blob"
 	"auth-service/storage/postgres"
 	"context"
+	"database/sql"
+	"errors"
 	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type AuthService struct {
 	pb.UnimplementedAuthServiceServer
-	User   *postgres.UserRepo
-	Logger *slog.Logger
+	User       *postgres.UserRepo
+	Session    *postgres.SessionRepo
+	Audit      *postgres.AuditRepo
+	Restaurant *postgres.RestaurantRepo
+	Blobs      blob.Store
+	Config     config.Config
+	Logger     *slog.Logger
 }
 
-func NewAuthService(user *postgres.UserRepo) *AuthService {
-	return &AuthService{User: user}
+func NewAuthService(db *sql.DB, logger *slog.Logger) *AuthService {
+	cfg := config.Load()
+	return &AuthService{
+		User:       postgres.NewUserRepo(db),
+		Session:    postgres.NewSessionRepo(db),
+		Audit:      postgres.NewAuditRepo(db),
+		Restaurant: postgres.NewRestaurantRepo(db),
+		Blobs:      blob.New(cfg),
+		Config:     cfg,
+		Logger:     logger,
+	}
 }
 
+// GetUserProfile username bo'yicha profil. Admin, users:read ruxsatli API
+// kalit yoki foydalanuvchining o'zi o'qiy oladi.
 func (a *AuthService) GetUserProfile(ctx context.Context, in *pb.GetUserProfileRequest) (*pb.GetUserProfileResponse, error) {
 	a.Logger.Info("gRPC method GetUserProfile")
+	p, ok := principal.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, tr(ctx, "authorization is required"))
+	}
+	if !p.HasPermission(apikey.PermissionUsersRead) {
+		return nil, status.Error(codes.PermissionDenied, tr(ctx, "API key lacks permission %s", apikey.PermissionUsersRead))
+	}
+
 	resp, err := a.User.GetUserProfile(in.Username)
+	if !p.IsAPIKey() && !isAdmin(p) {
+		// Eski username ham shu foydalanuvchiga olib kelishi mumkin, shuning uchun profil solishtiriladi
+		own, ownErr := a.User.GetUserProfileById(p.UserId)
+		if err != nil || ownErr != nil || !strings.EqualFold(own.Username, resp.Username) {
+			return nil, status.Error(codes.PermissionDenied, tr(ctx, "access to this user is not allowed"))
+		}
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "profile not found"))
+	}
 	if err != nil {
 		a.Logger.Error("Error getting user profile:", "error", err.Error())
 		return nil, err
@@ -28,34 +74,86 @@ func (a *AuthService) GetUserProfile(ctx context.Context, in *pb.GetUserProfileR
 	return resp, nil
 }
 
+// UpdateUserProfile foydalanuvchining o'zi yoki admin uchun
 func (a *AuthService) UpdateUserProfile(ctx context.Context, in *pb.UpdateUserProfileRequest) (*pb.UpdateUserProfileResponse, error) {
 	a.Logger.Info("gRPC method UpdateUserProfile")
-	resp, err := a.User.UpdateUserProfile(in)
+	if _, err := requireSelfOrAdmin(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+
+	return a.updateProfile(ctx, in.UserId, in)
+}
+
+// updateProfile in.UserId o'rniga userId profilini yangilaydi
+func (a *AuthService) updateProfile(ctx context.Context, userId string, in *pb.UpdateUserProfileRequest) (*pb.UpdateUserProfileResponse, error) {
+	fields, err := profile.FromRequest(in)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, tr(ctx, err.Error()))
+	}
+	if in.Version <= 0 {
+		return nil, status.Error(codes.FailedPrecondition, tr(ctx, "version is required"))
+	}
+
+	before, err := a.User.GetUserProfileById(userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "profile not found"))
+	}
+	if err != nil {
+		a.Logger.Error("Error getting user profile:", "error", err.Error())
+		return nil, err
+	}
 
+	version, err := a.User.UpdateProfileFields(userId, fields, in.Version, profileChange(ctx))
+	if errors.Is(err, postgres.ErrVersionConflict) {
+		return nil, status.Error(codes.FailedPrecondition, tr(ctx, "profile was changed, current version is %d", version))
+	}
 	if err != nil {
 		a.Logger.Error("Error updating user profile:", "error", err.Error())
 		return &pb.UpdateUserProfileResponse{
-			Message: resp.Message,
+			Message: tr(ctx, "Failed to update user"),
 		}, err
 	}
 
+	after, err := a.User.GetUserProfileById(userId)
+	if err != nil {
+		a.Logger.Error("Error getting user profile:", "error", err.Error())
+		return nil, err
+	}
+
+	a.audit(ctx, audit.ActionProfileUpdate, "user", userId, before, after)
+
 	return &pb.UpdateUserProfileResponse{
-		Message: resp.Message,
+		Message: tr(ctx, "User updated successfully"),
+		Version: after.Version,
 	}, nil
 }
 
+// LogoutUser foydalanuvchining barcha sessiyalarini bekor qiladi. O'zi
+// yoki admin chaqira oladi. Hisobni o'chirmaydi.
 func (a *AuthService) LogoutUser(ctx context.Context, in *pb.LogoutRequest) (*pb.LogoutResponse, error) {
 	a.Logger.Info("gRPC method LogoutUser")
-	resp, err := a.User.LogoutUser(in.UserId)
+	if _, err := requireSelfOrAdmin(ctx, in.UserId); err != nil {
+		return nil, err
+	}
 
+	_, err := a.User.GetAccountStatus(in.UserId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "user not found"))
+	}
+	if err != nil {
+		a.Logger.Error("Error getting account status:", "error", err.Error())
+		return nil, err
+	}
+
+	revoked, err := a.Session.RevokeOtherSessions(in.UserId, "")
 	if err != nil {
 		a.Logger.Error("Error logging out user:", "error", err.Error())
-		return &pb.LogoutResponse{
-			Message: resp.Message,
-		}, err
+		return nil, err
 	}
 
+	a.audit(ctx, audit.ActionForceLogout, "user", in.UserId, nil, map[string]interface{}{"revoked": revoked})
+
 	return &pb.LogoutResponse{
-		Message: resp.Message,
+		Message: tr(ctx, "Sessions revoked successfully"),
 	}, nil
 }
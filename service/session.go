@@ -0,0 +1,61 @@
+package service
+
+import (
+	pb "auth-service/generated/auth_service"
+	"context"
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *AuthService) ListSessions(ctx context.Context, in *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	a.Logger.Info("gRPC method ListSessions")
+	if _, err := requireSelfOrAdmin(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+	resp, err := a.Session.ListSessions(in)
+	if err != nil {
+		a.Logger.Error("Error listing sessions:", "error", err.Error())
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (a *AuthService) RevokeSession(ctx context.Context, in *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	a.Logger.Info("gRPC method RevokeSession")
+	if _, err := requireSelfOrAdmin(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+	err := a.Session.RevokeSession(in.UserId, in.SessionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "session not found"))
+	}
+	if err != nil {
+		a.Logger.Error("Error revoking session:", "error", err.Error())
+		return nil, err
+	}
+
+	return &pb.RevokeSessionResponse{
+		Message: tr(ctx, "Session revoked successfully"),
+	}, nil
+}
+
+func (a *AuthService) RevokeOtherSessions(ctx context.Context, in *pb.RevokeOtherSessionsRequest) (*pb.RevokeOtherSessionsResponse, error) {
+	a.Logger.Info("gRPC method RevokeOtherSessions")
+	if _, err := requireSelfOrAdmin(ctx, in.UserId); err != nil {
+		return nil, err
+	}
+	revoked, err := a.Session.RevokeOtherSessions(in.UserId, in.CurrentSessionId)
+	if err != nil {
+		a.Logger.Error("Error revoking sessions:", "error", err.Error())
+		return nil, err
+	}
+
+	return &pb.RevokeOtherSessionsResponse{
+		Message: tr(ctx, "Other sessions revoked successfully"),
+		Revoked: revoked,
+	}, nil
+}
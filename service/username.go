@@ -0,0 +1,56 @@
+package service
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/username"
+	pb "auth-service/generated/auth_service"
+	"auth-service/storage/postgres"
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (a *AuthService) ChangeUsername(ctx context.Context, in *pb.ChangeUsernameRequest) (*pb.ChangeUsernameResponse, error) {
+	a.Logger.Info("gRPC method ChangeUsername")
+
+	p, err := requireSelfOrAdmin(ctx, in.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if p.ActorId != "" {
+		return nil, status.Error(codes.PermissionDenied, tr(ctx, "cannot change username while impersonating"))
+	}
+
+	name := strings.TrimSpace(in.Username)
+	if err := username.Validate(name); err != nil {
+		return nil, status.Error(codes.InvalidArgument, tr(ctx, err.Error()))
+	}
+
+	cooldown := time.Duration(a.Config.USERNAME_COOLDOWN_DAYS) * 24 * time.Hour
+	old, err := a.User.ChangeUsername(in.UserId, name, cooldown, profileChange(ctx))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, tr(ctx, "user not found"))
+	}
+	if errors.Is(err, postgres.ErrUsernameTaken) {
+		return nil, status.Error(codes.AlreadyExists, tr(ctx, err.Error()))
+	}
+	if err != nil {
+		a.Logger.Error("Error changing username:", "error", err.Error())
+		return nil, err
+	}
+
+	if old != name {
+		a.audit(ctx, audit.ActionUsernameChange, "user", in.UserId, map[string]string{"username": old}, map[string]string{"username": name})
+	}
+
+	return &pb.ChangeUsernameResponse{
+		Message:     tr(ctx, "Username changed successfully"),
+		OldUsername: old,
+		Username:    name,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package blob
+
+import (
+	"auth-service/config"
+	"context"
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// Store fayllarni kalit bo'yicha saqlaydi. Kalitlar "/" bilan ajratilgan
+// nisbiy yo'llar, masalan avatars/<user_id>/<version>_256.jpg.
+type Store interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// DeletePrefix shu bilan boshlanadigan barcha kalitlarni o'chiradi
+	DeletePrefix(ctx context.Context, prefix string) error
+	// URL kalitni klient yuklab oladigan ochiq manzil
+	URL(key string) string
+}
+
+// New konfiguratsiyaga ko'ra store qaytaradi. Hozircha faqat lokal disk.
+func New(cfg config.Config) Store {
+	return &LocalStore{
+		Dir:     cfg.BLOB_DIR,
+		BaseURL: cfg.API_URL + "/media",
+	}
+}
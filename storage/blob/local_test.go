@@ -0,0 +1,39 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStore(t *testing.T) {
+	ctx := context.Background()
+	s := &LocalStore{Dir: t.TempDir(), BaseURL: "http://localhost:8081/media"}
+
+	require.NoError(t, s.Put(ctx, "avatars/u1/v1_64.jpg", []byte("small"), "image/jpeg"))
+	require.NoError(t, s.Put(ctx, "avatars/u1/v1_256.jpg", []byte("large"), "image/jpeg"))
+	require.NoError(t, s.Put(ctx, "avatars/u1/v2_64.jpg", []byte("new"), "image/jpeg"))
+
+	r, err := s.Open(ctx, "avatars/u1/v1_64.jpg")
+	require.NoError(t, err)
+	data, _ := io.ReadAll(r)
+	r.Close()
+	assert.Equal(t, "small", string(data))
+
+	require.NoError(t, s.DeletePrefix(ctx, "avatars/u1/v1"))
+	_, err = s.Open(ctx, "avatars/u1/v1_256.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = s.Open(ctx, "avatars/u1/v2_64.jpg")
+	assert.NoError(t, err)
+
+	require.NoError(t, s.DeletePrefix(ctx, "avatars/u1/"))
+	_, err = s.Open(ctx, "avatars/u1/v2_64.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.Open(ctx, "../etc/passwd")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, "http://localhost:8081/media/avatars/u1/v2_64.jpg", s.URL("avatars/u1/v2_64.jpg"))
+}
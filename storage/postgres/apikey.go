@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+type APIKeyRepo struct {
+	DB *sql.DB
+}
+
+func NewAPIKeyRepo(db *sql.DB) *APIKeyRepo {
+	return &APIKeyRepo{DB: db}
+}
+
+const apiKeyColumns = `
+			id,
+			restaurant_id,
+			name,
+			prefix,
+			permissions,
+			created_at,
+			COALESCE(expires_at::TEXT, ''),
+			COALESCE(last_used_at::TEXT, '')`
+
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (*pb.APIKey, error) {
+	var key pb.APIKey
+	err := row.Scan(&key.Id, &key.RestaurantId, &key.Name, &key.Prefix, pq.Array(&key.Permissions),
+		&key.CreatedAt, &key.ExpiresAt, &key.LastUsedAt)
+	return &key, err
+}
+
+func (a *APIKeyRepo) CreateAPIKey(in *pb.CreateAPIKeyRequest, prefix, keyHash, createdBy string) (*pb.APIKey, error) {
+	return scanAPIKey(a.DB.QueryRow(`
+		INSERT INTO api_keys (
+			restaurant_id,
+			name,
+			prefix,
+			key_hash,
+			permissions,
+			created_by,
+			expires_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			$6,
+			NULLIF($7, '')::TIMESTAMPTZ
+		)
+		RETURNING`+apiKeyColumns,
+		in.RestaurantId, in.Name, prefix, keyHash, pq.Array(in.Permissions), createdBy, in.ExpiresAt))
+}
+
+func (a *APIKeyRepo) ListAPIKeys(restaurantId string) (*pb.ListAPIKeysResponse, error) {
+	rows, err := a.DB.Query(`
+		SELECT`+apiKeyColumns+`
+		FROM
+			api_keys
+		WHERE
+			restaurant_id::TEXT = $1 AND revoked_at IS NULL
+		ORDER BY
+			created_at DESC
+	`, restaurantId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := &pb.ListAPIKeysResponse{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		resp.ApiKeys = append(resp.ApiKeys, key)
+	}
+
+	return resp, rows.Err()
+}
+
+func (a *APIKeyRepo) GetAPIKey(restaurantId, id string) (*pb.APIKey, error) {
+	return scanAPIKey(a.DB.QueryRow(`
+		SELECT`+apiKeyColumns+`
+		FROM
+			api_keys
+		WHERE
+			id::TEXT = $1 AND restaurant_id::TEXT = $2 AND revoked_at IS NULL
+	`, id, restaurantId))
+}
+
+func (a *APIKeyRepo) UpdateAPIKey(in *pb.UpdateAPIKeyRequest) (*pb.APIKey, error) {
+	return scanAPIKey(a.DB.QueryRow(`
+		UPDATE
+			api_keys
+		SET
+			name = $1,
+			permissions = $2
+		WHERE
+			id::TEXT = $3 AND restaurant_id::TEXT = $4 AND revoked_at IS NULL
+		RETURNING`+apiKeyColumns,
+		in.Name, pq.Array(in.Permissions), in.Id, in.RestaurantId))
+}
+
+// RevokeAPIKey kalit topilmasa sql.ErrNoRows qaytaradi
+func (a *APIKeyRepo) RevokeAPIKey(restaurantId, id string) error {
+	res, err := a.DB.Exec(`
+		UPDATE
+			api_keys
+		SET
+			revoked_at = CURRENT_TIMESTAMP
+		WHERE
+			id::TEXT = $1 AND restaurant_id::TEXT = $2 AND revoked_at IS NULL
+	`, id, restaurantId)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Authenticate amal qilayotgan kalitni qaytaradi va last_used_at ni
+// daqiqasiga ko'pi bilan bir marta yangilaydi
+func (a *APIKeyRepo) Authenticate(keyHash string) (*pb.APIKey, error) {
+	return scanAPIKey(a.DB.QueryRow(`
+		WITH key AS (
+			SELECT
+				*
+			FROM
+				api_keys
+			WHERE
+				key_hash = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		), touched AS (
+			UPDATE
+				api_keys
+			SET
+				last_used_at = CURRENT_TIMESTAMP
+			WHERE
+				id IN (SELECT id FROM key) AND (last_used_at IS NULL OR last_used_at < CURRENT_TIMESTAMP - INTERVAL '1 minute')
+		)
+		SELECT`+apiKeyColumns+`
+		FROM
+			key
+	`, keyHash))
+}
@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"auth-service/audit"
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// genesisHash zanjirdagi birinchi yozuvning prev_hash qiymati
+var genesisHash = strings.Repeat("0", 64)
+
+type AuditRepo struct {
+	DB *sql.DB
+}
+
+func NewAuditRepo(db *sql.DB) *AuditRepo {
+	return &AuditRepo{DB: db}
+}
+
+// Append yozuvni zanjir oxiriga qo'shadi. Advisory lock bir vaqtda
+// yozayotgan instancelar zanjirni ikkiga ajratib yubormasligi uchun kerak.
+func (a *AuditRepo) Append(entry *audit.Entry) error {
+	tx, err := a.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('audit_logs'))`)
+	if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(`
+		SELECT
+			hash
+		FROM
+			audit_logs
+		ORDER BY
+			id DESC
+		LIMIT 1
+	`).Scan(&entry.PrevHash)
+	if err == sql.ErrNoRows {
+		entry.PrevHash = genesisHash
+	} else if err != nil {
+		return err
+	}
+
+	entry.CreatedAt = time.Now().UTC().Truncate(time.Microsecond)
+	entry.PayloadHash = entry.ComputePayloadHash()
+	entry.Hash = entry.ComputeHash()
+
+	err = tx.QueryRow(`
+		INSERT INTO audit_logs (
+			actor_id,
+			action,
+			target_type,
+			target_id,
+			before,
+			after,
+			request_id,
+			created_at,
+			prev_hash,
+			hash,
+			payload_hash
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			NULLIF($5, '')::JSONB,
+			NULLIF($6, '')::JSONB,
+			$7,
+			$8,
+			$9,
+			$10,
+			$11
+		)
+		RETURNING
+			id
+	`, entry.ActorId, entry.Action, entry.TargetType, entry.TargetId, entry.Before, entry.After,
+		entry.RequestId, entry.CreatedAt, entry.PrevHash, entry.Hash, entry.PayloadHash).Scan(&entry.Id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (a *AuditRepo) ListAuditLogs(in *pb.ListAuditLogsRequest) (*pb.ListAuditLogsResponse, error) {
+	f := &filter{}
+	f.add("actor_id = $%d", in.ActorId)
+	f.add("action = $%d", in.Action)
+	f.add("target_type = $%d", in.TargetType)
+	f.add("target_id = $%d", in.TargetId)
+	f.add("created_at >= $%d::TIMESTAMPTZ", in.From)
+	f.add("created_at < $%d::TIMESTAMPTZ", in.To)
+
+	resp := &pb.ListAuditLogsResponse{}
+	err := a.DB.QueryRow(`
+		SELECT
+			COUNT(*)
+		FROM
+			audit_logs
+		`+f.where(), f.args...).Scan(&resp.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := pagination(in.Limit, in.Offset)
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			COALESCE(actor_id, ''),
+			action,
+			COALESCE(target_type, ''),
+			COALESCE(target_id, ''),
+			COALESCE(before::TEXT, ''),
+			COALESCE(after::TEXT, ''),
+			COALESCE(request_id, ''),
+			created_at,
+			hash
+		FROM
+			audit_logs
+		%s
+		ORDER BY
+			id DESC
+		LIMIT $%d OFFSET $%d
+	`, f.where(), f.arg(limit), f.arg(offset))
+
+	rows, err := a.DB.Query(query, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var log pb.AuditLog
+		err := rows.Scan(&log.Id, &log.ActorId, &log.Action, &log.TargetType, &log.TargetId,
+			&log.Before, &log.After, &log.RequestId, &log.CreatedAt, &log.Hash)
+		if err != nil {
+			return nil, err
+		}
+		resp.Logs = append(resp.Logs, &log)
+	}
+
+	return resp, rows.Err()
+}
+
+// Verify butun zanjir bo'ylab hashlarni qayta hisoblaydi va birinchi mos
+// kelmagan yozuvda to'xtaydi
+func (a *AuditRepo) Verify() (*pb.VerifyAuditLogResponse, error) {
+	rows, err := a.DB.Query(`
+		SELECT
+			id,
+			COALESCE(actor_id, ''),
+			action,
+			COALESCE(target_type, ''),
+			COALESCE(target_id, ''),
+			COALESCE(before::TEXT, ''),
+			COALESCE(after::TEXT, ''),
+			COALESCE(request_id, ''),
+			created_at,
+			prev_hash,
+			hash,
+			COALESCE(payload_hash, ''),
+			redacted_at IS NOT NULL
+		FROM
+			audit_logs
+		ORDER BY
+			id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := &pb.VerifyAuditLogResponse{Valid: true}
+	prev := genesisHash
+	for rows.Next() {
+		var (
+			e        audit.Entry
+			redacted bool
+		)
+		err := rows.Scan(&e.Id, &e.ActorId, &e.Action, &e.TargetType, &e.TargetId, &e.Before,
+			&e.After, &e.RequestId, &e.CreatedAt, &e.PrevHash, &e.Hash, &e.PayloadHash, &redacted)
+		if err != nil {
+			return nil, err
+		}
+		resp.Checked++
+
+		// Hash har doim qayta hisoblanadi. Purge shaxsiy maydonlarni o'chirgan
+		// yozuvda faqat before/after digest bilan solishtirilmaydi.
+		payloadOK := redacted || e.PayloadHash == "" || e.ComputePayloadHash() == e.PayloadHash
+		if e.PrevHash != prev || e.ComputeHash() != e.Hash || !payloadOK {
+			resp.Valid = false
+			resp.BrokenId = e.Id
+			return resp, nil
+		}
+		prev = e.Hash
+	}
+
+	return resp, rows.Err()
+}
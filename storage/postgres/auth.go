@@ -1,9 +1,12 @@
 package postgres
 
 import (
+	"auth-service/auth/identifier"
+	"auth-service/auth/lifecycle"
 	pb "auth-service/generated/auth_service"
+	"auth-service/profile"
 	"database/sql"
-	"fmt"
+	"strings"
 )
 
 type UserRepo struct {
@@ -25,16 +28,18 @@ func (u *UserRepo) CreateUser(user *pb.RegisterRequest) (*pb.RegisterResponse, e
 		INSERT INTO users (
 			username,
 			password,
-			email
+			email,
+			status
 		)
 		VALUES (
 			$1,
 			$2,
-			$3
+			$3,
+			$4
 		)
 		RETURNING
 			id
-	`, user.Username, user.Password, user.Email).Scan(&userId)
+	`, user.Username, user.Password, user.Email, lifecycle.StatusPending).Scan(&userId)
 
 	if err != nil {
 		return &pb.RegisterResponse{
@@ -61,6 +66,13 @@ func (u *UserRepo) CreateUser(user *pb.RegisterRequest) (*pb.RegisterResponse, e
 		}, err
 	}
 
+	err = recordProfileVersion(tx, userId, profile.Change{ActorId: userId, Channel: profile.ChannelSignup})
+	if err != nil {
+		return &pb.RegisterResponse{
+			Message: "Failed to create user",
+		}, err
+	}
+
 	return &pb.RegisterResponse{
 		Message: "User created successfully",
 		UserId: userId,
@@ -69,85 +81,109 @@ func (u *UserRepo) CreateUser(user *pb.RegisterRequest) (*pb.RegisterResponse, e
 
 func (u *UserRepo) GetByEmail(email string) (*pb.LoginResponse, error) {
 	var user pb.LoginResponse
-	fmt.Println(email)
 	err := u.DB.QueryRow(`
 		SELECT
-			id,
-			username,
-			email,
-			password
+			u.id,
+			u.username,
+			u.email,
+			u.password,
+			u.role,
+			`+accountStatusSQL+`,
+			COALESCE(p.locale, ''),
+			COALESCE(p.timezone, '')
 		FROM
-			users
+			users u
+		LEFT JOIN
+			user_profiles p ON p.user_id = u.id
 		WHERE
-			email = $1
-	`, email).Scan(&user.UserId, &user.Username, &user.Email, &user.Password)
+			LOWER(u.email) = LOWER($1)
+	`, identifier.NormalizeEmail(email)).Scan(&user.UserId, &user.Username, &user.Email, &user.Password, &user.Role, &user.Status, &user.Locale, &user.Timezone)
 
 	return &user, err
 }
 
-func (u *UserRepo) LogoutUser(id string) (*pb.LogoutResponse, error) {
-	_, err := u.DB.Exec(`
-		UPDATE 
-			users 
-		SET
-			deleted_at = DATE_PART('epoch', CURRENT_TIMESTAMP)::INT
-		WHERE
-			deleted_at = 0 and id = $1
-	`, id)
-
-	if err != nil {
-		return &pb.LogoutResponse{
-			Message: "Faild to deleted user",
-		}, err
+// GetByIdentifier foydalanuvchini username, email yoki tasdiqlangan telefon
+// raqami bo'yicha katta-kichik harfga qaramay topadi
+func (u *UserRepo) GetByIdentifier(value string) (*pb.LoginResponse, error) {
+	switch identifier.Kind(value) {
+	case identifier.KindEmail:
+		return u.GetByEmail(value)
+	case identifier.KindPhone:
+		var user pb.LoginResponse
+		err := u.DB.QueryRow(`
+			SELECT
+				u.id,
+				u.username,
+				u.email,
+				u.password,
+				u.role,
+				`+accountStatusSQL+`,
+				COALESCE(p.locale, ''),
+				COALESCE(p.timezone, '')
+			FROM
+				users u
+			JOIN
+				user_profiles p ON p.user_id = u.id
+			WHERE
+				p.phone_number = $1 AND p.phone_verified
+		`, identifier.NormalizePhone(value)).Scan(&user.UserId, &user.Username, &user.Email, &user.Password, &user.Role, &user.Status, &user.Locale, &user.Timezone)
+		if err != sql.ErrNoRows {
+			return &user, err
+		}
 	}
 
-	return &pb.LogoutResponse{
-		Message: "user deleted successully",
-	}, nil
-}
-
-func (u *UserRepo) GetUserProfile(username string) (*pb.GetUserProfileResponse, error) {
-	var userProfile pb.GetUserProfileResponse
+	// Raqamlardan iborat username ham bo'lishi mumkin
+	var user pb.LoginResponse
 	err := u.DB.QueryRow(`
 		SELECT
-			fullname,
-			username,
-			date_of_birth,
-			phone_number,
-			address
+			u.id,
+			u.username,
+			u.email,
+			u.password,
+			u.role,
+			`+accountStatusSQL+`,
+			COALESCE(p.locale, ''),
+			COALESCE(p.timezone, '')
 		FROM
-			user_profiles
+			users u
+		LEFT JOIN
+			user_profiles p ON p.user_id = u.id
 		WHERE
-			username = $1
-	`, username).Scan(&userProfile.Fullname, &userProfile.Username, &userProfile.DateOfBirth, &userProfile.PhoneNumber, &userProfile.PhoneNumber)
+			LOWER(u.username) = LOWER($1)
+	`, strings.TrimSpace(value)).Scan(&user.UserId, &user.Username, &user.Email, &user.Password, &user.Role, &user.Status, &user.Locale, &user.Timezone)
 
-	return &userProfile, err
+	return &user, err
 }
 
-func (u *UserRepo) UpdateUserProfile(profile *pb.UpdateUserProfileRequest) (*pb.UpdateUserProfileResponse, error) {
-	_, err := u.DB.Exec(`
-		UPDATE 
+// GetUserProfile profilni username bo'yicha qaytaradi. Nom o'zgargan bo'lsa
+// eski nom ham oxirgi egasining joriy profiliga olib keladi.
+func (u *UserRepo) GetUserProfile(username string) (*pb.GetUserProfileResponse, error) {
+	var userProfile pb.GetUserProfileResponse
+	err := u.DB.QueryRow(`
+		SELECT
+			COALESCE(fullname, ''),
+			COALESCE(username, ''),
+			COALESCE(TO_CHAR(date_of_birth, 'YYYY-MM-DD'), ''),
+			COALESCE(phone_number, ''),
+			COALESCE(address, ''),
+			COALESCE(avatar_url, ''),
+			version,
+			COALESCE(locale, ''),
+			COALESCE(timezone, '')
+		FROM
 			user_profiles
-		SET
-			fullname = $1,
-			username = $2,
-			date_of_birth = $3,
-			phone_number = $4,
-			address = $5,
-			updated_at = CURRENT_TIMESTAMP
 		WHERE
-			user_id = $6
-	`, profile.FullName, profile.Username, profile.DateOfBirth, profile.PhoneNumber, profile.Address, profile.UserId)
+			LOWER(username) = LOWER($1)
+	`, strings.TrimSpace(username)).Scan(&userProfile.Fullname, &userProfile.Username, &userProfile.DateOfBirth, &userProfile.PhoneNumber, &userProfile.Address, &userProfile.AvatarUrl, &userProfile.Version, &userProfile.Locale, &userProfile.Timezone)
+	if err != sql.ErrNoRows {
+		return &userProfile, err
+	}
 
+	userId, err := u.resolveOldUsername(username)
 	if err != nil {
-		return &pb.UpdateUserProfileResponse{
-			Message: "Faild to updated user",
-		}, err
+		return &userProfile, err
 	}
-
-	return &pb.UpdateUserProfileResponse{
-		Message: "User updated successfully",
-	}, nil
+	return u.GetUserProfileById(userId)
 }
 
 func (u *UserRepo) EmailExists(email string) (bool, error) {
@@ -161,9 +197,121 @@ func (u *UserRepo) EmailExists(email string) (bool, error) {
 				FROM
 					users
 				WHERE
-					email = $1
+					LOWER(email) = LOWER($1)
 			)	
-	`, email).Scan(exists)
+	`, identifier.NormalizeEmail(email)).Scan(&exists)
 
 	return exists, err
 }
+
+func (u *UserRepo) UsernameExists(username string) (bool, error) {
+	var exists bool
+
+	err := u.DB.QueryRow(`
+		SELECT
+			EXISTS (
+				SELECT
+					1
+				FROM
+					users
+				WHERE
+					LOWER(username) = LOWER($1)
+			)
+	`, username).Scan(&exists)
+
+	return exists, err
+}
+
+// GetUserProfileById audit va admin uchun profilni user_id bo'yicha qaytaradi
+func (u *UserRepo) GetUserProfileById(userId string) (*pb.GetUserProfileResponse, error) {
+	var userProfile pb.GetUserProfileResponse
+	err := u.DB.QueryRow(`
+		SELECT
+			COALESCE(fullname, ''),
+			COALESCE(username, ''),
+			COALESCE(TO_CHAR(date_of_birth, 'YYYY-MM-DD'), ''),
+			COALESCE(phone_number, ''),
+			COALESCE(address, ''),
+			COALESCE(avatar_url, ''),
+			version,
+			COALESCE(locale, ''),
+			COALESCE(timezone, '')
+		FROM
+			user_profiles
+		WHERE
+			user_id = $1
+	`, userId).Scan(&userProfile.Fullname, &userProfile.Username, &userProfile.DateOfBirth, &userProfile.PhoneNumber, &userProfile.Address, &userProfile.AvatarUrl, &userProfile.Version, &userProfile.Locale, &userProfile.Timezone)
+
+	return &userProfile, err
+}
+
+// SetUserRole rolni o'zgartiradi va oldingi rolni qaytaradi
+func (u *UserRepo) SetUserRole(userId, role string) (string, error) {
+	var oldRole string
+	err := u.DB.QueryRow(`
+		UPDATE
+			users u
+		SET
+			role = $2,
+			updated_at = CURRENT_TIMESTAMP
+		FROM
+			(SELECT id, role FROM users WHERE id::TEXT = $1 FOR UPDATE) old
+		WHERE
+			u.id = old.id
+		RETURNING
+			old.role
+	`, userId, role).Scan(&oldRole)
+
+	return oldRole, err
+}
+
+func (u *UserRepo) GetUserById(id string) (*pb.LoginResponse, error) {
+	var user pb.LoginResponse
+	err := u.DB.QueryRow(`
+		SELECT
+			u.id,
+			COALESCE(u.username, ''),
+			COALESCE(u.email, ''),
+			u.role,
+			`+accountStatusSQL+`,
+			COALESCE(p.locale, ''),
+			COALESCE(p.timezone, '')
+		FROM
+			users u
+		LEFT JOIN
+			user_profiles p ON p.user_id = u.id
+		WHERE
+			u.id::TEXT = $1
+	`, id).Scan(&user.UserId, &user.Username, &user.Email, &user.Role, &user.Status, &user.Locale, &user.Timezone)
+
+	return &user, err
+}
+
+func (u *UserRepo) UpdatePassword(userId, hashedPassword string) error {
+	_, err := u.DB.Exec(`
+		UPDATE
+			users
+		SET
+			password = $1,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE
+			id = $2
+	`, hashedPassword, userId)
+
+	return err
+}
+
+// GetPasswordHash parolni qayta so'raladigan amallar uchun
+func (u *UserRepo) GetPasswordHash(userId string) (string, error) {
+	var password string
+	err := u.DB.QueryRow(`
+		SELECT
+			COALESCE(password, '')
+		FROM
+			users
+		WHERE
+			id::TEXT = $1
+	`, userId).Scan(&password)
+
+	return password, err
+}
@@ -1,11 +1,14 @@
 package postgres
 
 import (
+	"auth-service/auth/lifecycle"
 	pb "auth-service/generated/auth_service"
+	"auth-service/profile"
 	"database/sql"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -36,6 +39,10 @@ func TestRegister(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.Equal(t, expectedResponse.Message, resp.Message)
+
+	status, err := user.GetAccountStatus(resp.UserId)
+	assert.NoError(t, err)
+	assert.Equal(t, lifecycle.StatusPending, status.Status)
 }
 
 func TestLogin(t *testing.T) {
@@ -66,7 +73,7 @@ func TestLogin(t *testing.T) {
 	}
 }
 
-func TestLogoutUser(t *testing.T) {
+func TestAdminDeleteSchedulesDeletion(t *testing.T) {
 	db, err := ConnectDB()
 	if err != nil {
 		t.Fatal(err)
@@ -76,18 +83,18 @@ func TestLogoutUser(t *testing.T) {
 	user := NewUserRepo(db)
 
 	id := "ee681887-fb2f-4722-ab48-0d83dece505c"
-	resp, err := user.LogoutUser(id)
+	deleteAt := time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	_, err = user.SetStatus(id, lifecycle.StatusPendingDeletion, "deleted by admin", deleteAt)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	expectedResponse := &pb.LogoutResponse{
-		Message: "user deleted successully",
-	}
+	status, err := user.GetAccountStatus(id)
+	assert.NoError(t, err)
+	assert.Equal(t, lifecycle.StatusPendingDeletion, status.Status)
 
-	if !reflect.DeepEqual(resp, expectedResponse) {
-		t.Errorf("have %v, wont %v", resp, expectedResponse)
-	}
+	_, err = user.SetStatus("00000000-0000-0000-0000-000000000000", lifecycle.StatusPendingDeletion, "", deleteAt)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
 }
 
 func TestGetUserProfile(t *testing.T) {
@@ -99,21 +106,17 @@ func TestGetUserProfile(t *testing.T) {
 
 	user := NewUserRepo(db)
 
-	username := "diyorbeknematov"
+	username := "DiyorbekNematov"
 	resp, err := user.GetUserProfile(username)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	expectedResponse := &pb.GetUserProfileResponse{
-		Fullname: "Diyorbek Ne'matov",
-		Username: "diyorbeknematov",
-		DateOfBirth: "2004-11-20T00:00:00Z",
-	}
-
-	if !reflect.DeepEqual(resp, expectedResponse) {
-		t.Errorf("have %v, wont %v", resp, expectedResponse)
-	}
+	// Katta-kichik harfsiz qidiruv va YYYY-MM-DD sanasi tekshiriladi,
+	// qolgan maydonlar boshqa testlarda o'zgaradi
+	assert.Equal(t, "diyorbeknematov", resp.Username)
+	assert.Equal(t, "2004-11-20", resp.DateOfBirth)
+	assert.Positive(t, resp.Version)
 }
 
 func TestUpdateUserProfile(t *testing.T) {
@@ -124,23 +127,62 @@ func TestUpdateUserProfile(t *testing.T) {
 	defer db.Close()
 
 	user := NewUserRepo(db)
-	resp, err := user.UpdateUserProfile(&pb.UpdateUserProfileRequest{
-		UserId:      "fc27aae7-e777-45f1-9431-f00c31dfdea0",
-		Username:    "diyorbeknematov",
-		FullName:    "Diyorbek Ne'matov",
+	userId := "fc27aae7-e777-45f1-9431-f00c31dfdea0"
+	current, err := user.GetUserProfileById(userId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields, err := profile.FromRequest(&pb.UpdateUserProfileRequest{
+		UserId:      userId,
+		FullName:    "  Diyorbek Ne'matov ",
 		DateOfBirth: "2004-11-20",
-		PhoneNumber: "+998939955726",
+		PhoneNumber: "+998 93 995 57 26",
 		Address:     "",
+		Version:     current.Version,
 	})
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	expectedResponse := &pb.UpdateUserProfileResponse{
-		Message: "User updated successfully",
+	version, err := user.UpdateProfileFields(userId, fields, current.Version, profile.Change{ActorId: userId, Channel: profile.ChannelGRPC})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != current.Version+1 {
+		t.Errorf("have version %d wont %d", version, current.Version+1)
 	}
 
-	if !reflect.DeepEqual(resp, expectedResponse) {
-		t.Errorf("have %v wont %v", resp, expectedResponse)
+	updated, err := user.GetUserProfileById(userId)
+	if err != nil {
+		t.Fatal(err)
 	}
-}
\ No newline at end of file
+	if updated.Fullname != "Diyorbek Ne'matov" || updated.PhoneNumber != "+998939955726" {
+		t.Errorf("have %q %q wont normalized fullname and phone", updated.Fullname, updated.PhoneNumber)
+	}
+
+	if _, err := profile.FromRequest(&pb.UpdateUserProfileRequest{UserId: userId, PhoneNumber: "abc", Version: version}); err == nil {
+		t.Error("wont validation error for invalid phone number")
+	}
+}
+func TestGetByIdentifier(t *testing.T) {
+	db, err := ConnectDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	user := NewUserRepo(db)
+
+	byEmail, err := user.GetByIdentifier("DiyorbekNematov@Gmail.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byUsername, err := user.GetByIdentifier("DIYORBEKNEMATOV")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, byEmail.UserId, byUsername.UserId)
+}
@@ -0,0 +1,39 @@
+package postgres
+
+import "auth-service/profile"
+
+// SetAvatar yangi avatar kalit prefiksi va URL ini yozadi va oldingi
+// prefiksni qaytaradi (eski fayllarni o'chirish uchun). key bo'sh bo'lsa
+// avatar olib tashlanadi.
+func (u *UserRepo) SetAvatar(userId, key, url string, change profile.Change) (string, error) {
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var old string
+	err = tx.QueryRow(`
+		UPDATE
+			user_profiles p
+		SET
+			avatar_key = NULLIF($2, ''),
+			avatar_url = NULLIF($3, ''),
+			version = p.version + 1,
+			updated_at = CURRENT_TIMESTAMP
+		FROM
+			(SELECT user_id, avatar_key FROM user_profiles WHERE user_id::TEXT = $1 FOR UPDATE) old
+		WHERE
+			p.user_id = old.user_id
+		RETURNING
+			COALESCE(old.avatar_key, '')
+	`, userId, key, url).Scan(&old)
+	if err != nil {
+		return "", err
+	}
+
+	if err := recordProfileVersion(tx, userId, change); err != nil {
+		return "", err
+	}
+	return old, tx.Commit()
+}
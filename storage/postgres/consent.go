@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"auth-service/models"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ErrDocumentVersionExists shu turdagi hujjatning bu versiyasi allaqachon bor
+var ErrDocumentVersionExists = errors.New("document version already exists")
+
+// currentDocumentsSQL har bir turning oxirgi e'lon qilingan versiyasi
+const currentDocumentsSQL = `
+			SELECT DISTINCT ON (type)
+				id,
+				type,
+				version,
+				url,
+				published_at
+			FROM
+				legal_documents
+			ORDER BY
+				type, published_at DESC`
+
+const documentColumns = `
+			d.id,
+			d.type,
+			d.version,
+			d.url,
+			TO_CHAR(d.published_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')`
+
+type ConsentRepo struct {
+	DB *sql.DB
+}
+
+func NewConsentRepo(db *sql.DB) *ConsentRepo {
+	return &ConsentRepo{DB: db}
+}
+
+func scanDocuments(rows *sql.Rows) ([]*models.LegalDocument, error) {
+	defer rows.Close()
+
+	docs := []*models.LegalDocument{}
+	for rows.Next() {
+		var d models.LegalDocument
+		if err := rows.Scan(&d.Id, &d.Type, &d.Version, &d.Url, &d.PublishedAt); err != nil {
+			return nil, err
+		}
+		docs = append(docs, &d)
+	}
+
+	return docs, rows.Err()
+}
+
+// PublishLegalDocument yangi versiyani darhol amalga kiritadi. Keyingi
+// loginda foydalanuvchilardan uni qabul qilish so'raladi.
+func (c *ConsentRepo) PublishLegalDocument(doc *models.LegalDocument, publishedBy string) error {
+	err := c.DB.QueryRow(`
+		INSERT INTO legal_documents (
+			type,
+			version,
+			url,
+			published_by
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			NULLIF($4, '')::UUID
+		)
+		RETURNING
+			id,
+			TO_CHAR(published_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')
+	`, doc.Type, doc.Version, doc.Url, publishedBy).Scan(&doc.Id, &doc.PublishedAt)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrDocumentVersionExists
+	}
+	return err
+}
+
+// ListLegalDocuments barcha versiyalar, yangisi birinchi
+func (c *ConsentRepo) ListLegalDocuments() ([]*models.LegalDocument, error) {
+	rows, err := c.DB.Query(`
+		SELECT` + documentColumns + `
+		FROM
+			legal_documents d
+		ORDER BY
+			d.published_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDocuments(rows)
+}
+
+// CurrentLegalDocuments amaldagi versiyalar. Hech narsa e'lon qilinmagan
+// bo'lsa bo'sh ro'yxat.
+func (c *ConsentRepo) CurrentLegalDocuments() ([]*models.LegalDocument, error) {
+	rows, err := c.DB.Query(`
+		SELECT` + documentColumns + `
+		FROM (` + currentDocumentsSQL + `
+		) d
+		ORDER BY
+			d.type
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDocuments(rows)
+}
+
+// PendingLegalDocuments foydalanuvchi hali qabul qilmagan amaldagi versiyalar
+func (c *ConsentRepo) PendingLegalDocuments(userId string) ([]*models.LegalDocument, error) {
+	rows, err := c.DB.Query(`
+		SELECT`+documentColumns+`
+		FROM (`+currentDocumentsSQL+`
+		) d
+		WHERE
+			NOT EXISTS (
+				SELECT
+					1
+				FROM
+					consents
+				WHERE
+					user_id::TEXT = $1 AND document_id = d.id
+			)
+		ORDER BY
+			d.type
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDocuments(rows)
+}
+
+// AcceptLegalDocuments amaldagi hujjatlar qabul qilinganini yozadi. Eski
+// yoki noma'lum id lar e'tiborga olinmaydi, qayta qabul qilish vaqtni
+// o'zgartirmaydi.
+func (c *ConsentRepo) AcceptLegalDocuments(userId string, documentIds []string, ip, userAgent string) error {
+	_, err := c.DB.Exec(`
+		INSERT INTO consents (
+			user_id,
+			document_id,
+			ip,
+			user_agent
+		)
+		SELECT
+			$1,
+			d.id,
+			NULLIF($3, ''),
+			NULLIF(LEFT($4, 255), '')
+		FROM (`+currentDocumentsSQL+`
+		) d
+		WHERE
+			d.id::TEXT = ANY($2)
+		ON CONFLICT (user_id, document_id) DO NOTHING
+	`, userId, pq.Array(documentIds), ip, userAgent)
+
+	return err
+}
+
+// ListConsents foydalanuvchi qabul qilgan barcha versiyalar
+func (c *ConsentRepo) ListConsents(userId string) ([]*models.Consent, error) {
+	rows, err := c.DB.Query(`
+		SELECT
+			d.id,
+			d.type,
+			d.version,
+			TO_CHAR(c.accepted_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+			COALESCE(c.ip, ''),
+			COALESCE(c.user_agent, '')
+		FROM
+			consents c
+		JOIN
+			legal_documents d ON d.id = c.document_id
+		WHERE
+			c.user_id::TEXT = $1
+		ORDER BY
+			c.accepted_at DESC
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	consents := []*models.Consent{}
+	for rows.Next() {
+		var consent models.Consent
+		err := rows.Scan(&consent.DocumentId, &consent.Type, &consent.Version, &consent.AcceptedAt, &consent.Ip, &consent.UserAgent)
+		if err != nil {
+			return nil, err
+		}
+		consents = append(consents, &consent)
+	}
+
+	return consents, rows.Err()
+}
+
+// ConsentReport har bir versiyani o'chirilmagan hisoblarning qanchasi qabul
+// qilgani
+func (c *ConsentRepo) ConsentReport() (*models.ConsentReport, error) {
+	rows, err := c.DB.Query(`
+		WITH users_total AS (
+			SELECT
+				COUNT(*) AS n
+			FROM
+				users
+			WHERE
+				status <> 'deleted'
+		)
+		SELECT` + documentColumns + `,
+			d.id IN (SELECT id FROM (` + currentDocumentsSQL + `
+			) cur),
+			COUNT(u.id),
+			t.n
+		FROM
+			legal_documents d
+		CROSS JOIN
+			users_total t
+		LEFT JOIN
+			consents c ON c.document_id = d.id
+		LEFT JOIN
+			users u ON u.id = c.user_id AND u.status <> 'deleted'
+		GROUP BY
+			d.id, t.n
+		ORDER BY
+			d.type, d.published_at DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := &models.ConsentReport{Documents: []*models.ConsentReportEntry{}}
+	for rows.Next() {
+		var e models.ConsentReportEntry
+		err := rows.Scan(&e.DocumentId, &e.Type, &e.Version, &e.Url, &e.PublishedAt, &e.Current, &e.Accepted, &e.Users)
+		if err != nil {
+			return nil, err
+		}
+		if e.Users > 0 {
+			e.AcceptanceRate = float64(e.Accepted) / float64(e.Users)
+		}
+		report.Documents = append(report.Documents, &e)
+	}
+
+	return report, rows.Err()
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"database/sql"
+)
+
+type DeviceRepo struct {
+	DB *sql.DB
+}
+
+func NewDeviceRepo(db *sql.DB) *DeviceRepo {
+	return &DeviceRepo{DB: db}
+}
+
+// DeviceCheck foydalanuvchining oldingi kirishlari bilan solishtirish natijasi
+type DeviceCheck struct {
+	FirstDevice      bool
+	KnownFingerprint bool
+	KnownIPRange     bool
+}
+
+// Suspicious avval kirgan foydalanuvchi yangi qurilma yoki tarmoqdan kirsa true
+func (d *DeviceCheck) Suspicious() bool {
+	return !d.FirstDevice && (!d.KnownFingerprint || !d.KnownIPRange)
+}
+
+func (d *DeviceRepo) CheckDevice(userId, fingerprint, ipRange string) (*DeviceCheck, error) {
+	var check DeviceCheck
+	var total int
+	err := d.DB.QueryRow(`
+		SELECT
+			COUNT(*),
+			COALESCE(BOOL_OR(fingerprint = $2), FALSE),
+			COALESCE(BOOL_OR(ip_range = $3), FALSE)
+		FROM
+			known_devices
+		WHERE
+			user_id = $1
+	`, userId, fingerprint, ipRange).Scan(&total, &check.KnownFingerprint, &check.KnownIPRange)
+	check.FirstDevice = total == 0
+
+	return &check, err
+}
+
+func (d *DeviceRepo) RememberDevice(userId, fingerprint, ipRange, deviceName string) error {
+	_, err := d.DB.Exec(`
+		INSERT INTO known_devices (
+			user_id,
+			fingerprint,
+			ip_range,
+			device_name
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			LEFT($4, 100)
+		)
+		ON CONFLICT (user_id, fingerprint, ip_range) DO UPDATE SET
+			device_name = EXCLUDED.device_name,
+			last_seen_at = CURRENT_TIMESTAMP
+	`, userId, fingerprint, ipRange, deviceName)
+
+	return err
+}
+
+func (d *DeviceRepo) ForgetDevice(userId, fingerprint string) error {
+	_, err := d.DB.Exec(`
+		DELETE FROM
+			known_devices
+		WHERE
+			user_id = $1 AND fingerprint = $2
+	`, userId, fingerprint)
+
+	return err
+}
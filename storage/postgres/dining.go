@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/lib/pq"
+)
+
+// GetDiningPreferences hali saqlanmagan bo'lsa bo'sh afzalliklarni qaytaradi
+func (u *UserRepo) GetDiningPreferences(userId string) (*pb.DiningPreferences, error) {
+	prefs := pb.DiningPreferences{UserId: userId}
+	var occasions []byte
+	err := u.DB.QueryRow(`
+		SELECT
+			dietary_restrictions,
+			allergies,
+			COALESCE(seating, ''),
+			favorite_cuisines,
+			occasions,
+			TO_CHAR(updated_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')
+		FROM
+			dining_preferences
+		WHERE
+			user_id::TEXT = $1
+	`, userId).Scan(pq.Array(&prefs.DietaryRestrictions), pq.Array(&prefs.Allergies), &prefs.Seating,
+		pq.Array(&prefs.FavoriteCuisines), &occasions, &prefs.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return &prefs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &prefs, json.Unmarshal(occasions, &prefs.Occasions)
+}
+
+// SetDiningPreferences afzalliklarni to'liq almashtiradi
+func (u *UserRepo) SetDiningPreferences(prefs *pb.DiningPreferences) error {
+	occasions, err := json.Marshal(prefs.Occasions)
+	if err != nil {
+		return err
+	}
+	if prefs.Occasions == nil {
+		occasions = []byte("[]")
+	}
+
+	_, err = u.DB.Exec(`
+		INSERT INTO dining_preferences (
+			user_id,
+			dietary_restrictions,
+			allergies,
+			seating,
+			favorite_cuisines,
+			occasions
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			NULLIF($4, ''),
+			$5,
+			$6
+		)
+		ON CONFLICT (user_id) DO UPDATE SET
+			dietary_restrictions = EXCLUDED.dietary_restrictions,
+			allergies = EXCLUDED.allergies,
+			seating = EXCLUDED.seating,
+			favorite_cuisines = EXCLUDED.favorite_cuisines,
+			occasions = EXCLUDED.occasions,
+			updated_at = CURRENT_TIMESTAMP
+	`, prefs.UserId, pq.Array(prefs.DietaryRestrictions), pq.Array(prefs.Allergies), prefs.Seating,
+		pq.Array(prefs.FavoriteCuisines), occasions)
+
+	return err
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrEmailTaken yangi manzil boshqa foydalanuvchida
+	ErrEmailTaken = errors.New("email is already registered")
+	// ErrEmailChangeStale so'rov yaratilgandan keyin email boshqa yo'l bilan o'zgargan
+	ErrEmailChangeStale = errors.New("email has changed since the request")
+)
+
+type EmailChange struct {
+	Id       string
+	UserId   string
+	OldEmail string
+	NewEmail string
+	// Reverted undo tasdiqlangan almashtirishni ortga qaytarganini bildiradi
+	Reverted bool
+}
+
+type EmailChangeRepo struct {
+	DB *sql.DB
+}
+
+func NewEmailChangeRepo(db *sql.DB) *EmailChangeRepo {
+	return &EmailChangeRepo{DB: db}
+}
+
+// CreateEmailChange foydalanuvchining oldingi tugallanmagan so'rovlarini
+// bekor qilib yangisini yaratadi
+func (e *EmailChangeRepo) CreateEmailChange(change *EmailChange, confirmHash, undoHash string, ttl, undoTTL time.Duration) (string, error) {
+	tx, err := e.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE
+			email_changes
+		SET
+			cancelled_at = CURRENT_TIMESTAMP
+		WHERE
+			user_id = $1 AND confirmed_at IS NULL AND cancelled_at IS NULL
+	`, change.UserId)
+	if err != nil {
+		return "", err
+	}
+
+	var id string
+	err = tx.QueryRow(`
+		INSERT INTO email_changes (
+			user_id,
+			old_email,
+			new_email,
+			confirm_token_hash,
+			undo_token_hash,
+			expires_at,
+			undo_expires_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5,
+			CURRENT_TIMESTAMP + MAKE_INTERVAL(secs => $6),
+			CURRENT_TIMESTAMP + MAKE_INTERVAL(secs => $7)
+		)
+		RETURNING
+			id
+	`, change.UserId, change.OldEmail, change.NewEmail, confirmHash, undoHash, ttl.Seconds(), undoTTL.Seconds()).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit()
+}
+
+// ConfirmEmailChange yangi manzil tasdiqlanganda emailni almashtiradi
+func (e *EmailChangeRepo) ConfirmEmailChange(confirmHash string) (*EmailChange, error) {
+	tx, err := e.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var change EmailChange
+	err = tx.QueryRow(`
+		SELECT
+			id,
+			user_id,
+			old_email,
+			new_email
+		FROM
+			email_changes
+		WHERE
+			confirm_token_hash = $1 AND confirmed_at IS NULL AND cancelled_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		FOR UPDATE
+	`, confirmHash).Scan(&change.Id, &change.UserId, &change.OldEmail, &change.NewEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := setEmail(tx, change.UserId, change.OldEmail, change.NewEmail); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(`
+		UPDATE
+			email_changes
+		SET
+			confirmed_at = CURRENT_TIMESTAMP
+		WHERE
+			id = $1
+	`, change.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &change, tx.Commit()
+}
+
+// UndoEmailChange eski manzildagi havola orqali so'rovni bekor qiladi.
+// Almashtirish tasdiqlangan bo'lsa eski email qaytariladi.
+func (e *EmailChangeRepo) UndoEmailChange(undoHash string) (*EmailChange, error) {
+	tx, err := e.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var change EmailChange
+	err = tx.QueryRow(`
+		SELECT
+			id,
+			user_id,
+			old_email,
+			new_email,
+			confirmed_at IS NOT NULL
+		FROM
+			email_changes
+		WHERE
+			undo_token_hash = $1 AND cancelled_at IS NULL AND undo_expires_at > CURRENT_TIMESTAMP
+		FOR UPDATE
+	`, undoHash).Scan(&change.Id, &change.UserId, &change.OldEmail, &change.NewEmail, &change.Reverted)
+	if err != nil {
+		return nil, err
+	}
+
+	if change.Reverted {
+		if err := setEmail(tx, change.UserId, change.NewEmail, change.OldEmail); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(`
+		UPDATE
+			email_changes
+		SET
+			cancelled_at = CURRENT_TIMESTAMP
+		WHERE
+			id = $1
+	`, change.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &change, tx.Commit()
+}
+
+// setEmail email hali from bo'lsagina uni to ga o'zgartiradi
+func setEmail(tx *sql.Tx, userId, from, to string) error {
+	res, err := tx.Exec(`
+		UPDATE
+			users
+		SET
+			email = $3,
+			email_verified = TRUE,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE
+			id = $1 AND email = $2
+	`, userId, from, to)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return ErrEmailTaken
+	}
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err == nil && n == 0 {
+		return ErrEmailChangeStale
+	}
+	return err
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import "time"
+
+// CreateEmailVerification oldingi ishlatilmagan havolalarni bekor qilib
+// yangisini saqlaydi
+func (u *UserRepo) CreateEmailVerification(userId, email, tokenHash string, ttl time.Duration) error {
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE
+			email_verifications
+		SET
+			used_at = CURRENT_TIMESTAMP
+		WHERE
+			user_id::TEXT = $1 AND used_at IS NULL
+	`, userId)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO email_verifications (
+			user_id,
+			email,
+			token_hash,
+			expires_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			CURRENT_TIMESTAMP + $4 * INTERVAL '1 second'
+		)
+	`, userId, email, tokenHash, int64(ttl.Seconds()))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// VerifyEmail havolani ishlatilgan deb belgilaydi, emailni tasdiqlaydi va
+// pending_verification hisobni faollashtiradi. Noto'g'ri, eskirgan havola
+// uchun sql.ErrNoRows, havola yuborilgandan keyin email o'zgargan bo'lsa
+// ErrEmailChangeStale.
+func (u *UserRepo) VerifyEmail(tokenHash string) (string, error) {
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var userId, email string
+	err = tx.QueryRow(`
+		UPDATE
+			email_verifications
+		SET
+			used_at = CURRENT_TIMESTAMP
+		WHERE
+			token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING
+			user_id,
+			email
+	`, tokenHash).Scan(&userId, &email)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := tx.Exec(`
+		UPDATE
+			users
+		SET
+			email_verified = TRUE,
+			status = CASE WHEN status = 'pending_verification' THEN 'active' ELSE status END,
+			status_changed_at = CASE WHEN status = 'pending_verification' THEN CURRENT_TIMESTAMP ELSE status_changed_at END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE
+			id = $1 AND email = $2
+	`, userId, email)
+	if err != nil {
+		return "", err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if n == 0 {
+		return "", ErrEmailChangeStale
+	}
+
+	return userId, tx.Commit()
+}
+
+// PendingVerification email bo'yicha hali tasdiqlanmagan hisobning id si va
+// saqlangan emailini qaytaradi. Bunday hisob bo'lmasa sql.ErrNoRows.
+func (u *UserRepo) PendingVerification(email string) (string, string, error) {
+	var userId, stored string
+	err := u.DB.QueryRow(`
+		SELECT
+			id,
+			email
+		FROM
+			users
+		WHERE
+			LOWER(email) = LOWER($1) AND status = 'pending_verification'
+	`, email).Scan(&userId, &stored)
+	if err != nil {
+		return "", "", err
+	}
+	return userId, stored, nil
+}
@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+	"time"
+)
+
+const (
+	ExportStatusPending    = "pending"
+	ExportStatusProcessing = "processing"
+	ExportStatusReady      = "ready"
+	ExportStatusFailed     = "failed"
+	ExportStatusExpired    = "expired"
+)
+
+// ClaimedExport worker olgan eksport vazifasi
+type ClaimedExport struct {
+	Id     string
+	UserId string
+}
+
+type ExportRepo struct {
+	DB *sql.DB
+}
+
+func NewExportRepo(db *sql.DB) *ExportRepo {
+	return &ExportRepo{DB: db}
+}
+
+// CreateExport yangi eksport so'rovini yaratadi. Foydalanuvchida tugallanmagan
+// so'rov bo'lsa yangisi yaratilmaydi va o'sha qaytariladi.
+func (e *ExportRepo) CreateExport(userId string) (*pb.DataExport, error) {
+	export, err := e.scanExport(e.DB.QueryRow(`
+		SELECT
+			id,
+			status,
+			created_at,
+			completed_at,
+			expires_at
+		FROM
+			data_exports
+		WHERE
+			user_id::TEXT = $1 AND status IN ('pending', 'processing')
+		LIMIT 1
+	`, userId))
+	if err != sql.ErrNoRows {
+		return export, err
+	}
+
+	return e.scanExport(e.DB.QueryRow(`
+		INSERT INTO data_exports (
+			user_id
+		)
+		VALUES (
+			$1
+		)
+		RETURNING
+			id,
+			status,
+			created_at,
+			completed_at,
+			expires_at
+	`, userId))
+}
+
+func (e *ExportRepo) GetExport(userId, id string) (*pb.DataExport, error) {
+	return e.scanExport(e.DB.QueryRow(`
+		SELECT
+			id,
+			status,
+			created_at,
+			completed_at,
+			expires_at
+		FROM
+			data_exports
+		WHERE
+			user_id::TEXT = $1 AND id::TEXT = $2
+	`, userId, id))
+}
+
+func (e *ExportRepo) ListExports(userId string) (*pb.ListDataExportsResponse, error) {
+	rows, err := e.DB.Query(`
+		SELECT
+			id,
+			status,
+			created_at,
+			completed_at,
+			expires_at
+		FROM
+			data_exports
+		WHERE
+			user_id::TEXT = $1
+		ORDER BY
+			created_at DESC
+		LIMIT 20
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := &pb.ListDataExportsResponse{}
+	for rows.Next() {
+		export, err := e.scanExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		resp.Exports = append(resp.Exports, export)
+	}
+
+	return resp, rows.Err()
+}
+
+// ReadyFile tayyor va muddati o'tmagan eksport faylining yo'li
+func (e *ExportRepo) ReadyFile(id string) (string, error) {
+	var path string
+	err := e.DB.QueryRow(`
+		SELECT
+			file_path
+		FROM
+			data_exports
+		WHERE
+			id::TEXT = $1 AND status = 'ready' AND expires_at > CURRENT_TIMESTAMP
+	`, id).Scan(&path)
+
+	return path, err
+}
+
+// ClaimNext navbatdagi eksportni processing holatiga o'tkazib qaytaradi.
+// Bir nechta worker bo'lsa ham bitta vazifani faqat bittasi oladi. lease dan
+// uzoq processing da qolgan vazifa (worker to'xtab qolgan) qayta olinadi.
+func (e *ExportRepo) ClaimNext(lease time.Duration) (*ClaimedExport, error) {
+	var export ClaimedExport
+	err := e.DB.QueryRow(`
+		UPDATE
+			data_exports
+		SET
+			status = 'processing',
+			claimed_at = CURRENT_TIMESTAMP
+		WHERE
+			id = (
+				SELECT
+					id
+				FROM
+					data_exports
+				WHERE
+					status = 'pending'
+					OR (status = 'processing' AND COALESCE(claimed_at, created_at) <= CURRENT_TIMESTAMP - $1 * INTERVAL '1 second')
+				ORDER BY
+					created_at
+				LIMIT 1
+				FOR UPDATE SKIP LOCKED
+			)
+		RETURNING
+			id,
+			user_id
+	`, int64(lease.Seconds())).Scan(&export.Id, &export.UserId)
+
+	return &export, err
+}
+
+func (e *ExportRepo) CompleteExport(id, filePath string, expiresAt time.Time) error {
+	_, err := e.DB.Exec(`
+		UPDATE
+			data_exports
+		SET
+			status = 'ready',
+			file_path = $2,
+			completed_at = CURRENT_TIMESTAMP,
+			expires_at = $3
+		WHERE
+			id = $1 AND status = 'processing'
+	`, id, filePath, expiresAt)
+
+	return err
+}
+
+func (e *ExportRepo) FailExport(id, message string) error {
+	_, err := e.DB.Exec(`
+		UPDATE
+			data_exports
+		SET
+			status = 'failed',
+			error = $2,
+			completed_at = CURRENT_TIMESTAMP
+		WHERE
+			id = $1 AND status = 'processing'
+	`, id, message)
+
+	return err
+}
+
+// ExpireExports muddati o'tgan eksportlarni expired qiladi va fayllarini qaytaradi
+func (e *ExportRepo) ExpireExports() ([]string, error) {
+	rows, err := e.DB.Query(`
+		UPDATE
+			data_exports
+		SET
+			status = 'expired'
+		WHERE
+			status = 'ready' AND expires_at <= CURRENT_TIMESTAMP
+		RETURNING
+			file_path
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func (e *ExportRepo) scanExport(row rowScanner) (*pb.DataExport, error) {
+	var export pb.DataExport
+	var completedAt, expiresAt sql.NullTime
+	err := row.Scan(&export.Id, &export.Status, &export.CreatedAt, &completedAt, &expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		export.CompletedAt = completedAt.Time.UTC().Format(time.RFC3339)
+	}
+	if expiresAt.Valid {
+		export.ExpiresAt = expiresAt.Time.UTC().Format(time.RFC3339)
+	}
+	return &export, nil
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// isUUID id ni ::UUID ga cast qilish mumkinligini tekshiradi. id::TEXT = $1
+// o'rniga id = $1::UUID yozilsa primary key indeksi ishlatiladi.
+func isUUID(id string) bool {
+	return uuidPattern.MatchString(id)
+}
+
+// filter ixtiyoriy WHERE shartlarini va ularning argumentlarini yig'adi
+type filter struct {
+	conditions []string
+	args       []interface{}
+}
+
+// add value bo'sh bo'lmasa condition ni qo'shadi. condition dagi %d
+// argument raqami bilan almashtiriladi.
+func (f *filter) add(condition, value string) {
+	if value == "" {
+		return
+	}
+	f.conditions = append(f.conditions, fmt.Sprintf(condition, f.arg(value)))
+}
+
+// arg argument qo'shib uning raqamini qaytaradi
+func (f *filter) arg(value interface{}) int {
+	f.args = append(f.args, value)
+	return len(f.args)
+}
+
+func (f *filter) where() string {
+	if len(f.conditions) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(f.conditions, " AND ")
+}
+
+// pagination limit ni 1..100 oralig'ida ushlab turadi
+func pagination(limit, offset int32) (int32, int32) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
@@ -0,0 +1,279 @@
+package postgres
+
+import (
+	"auth-service/audit"
+	"auth-service/auth/lifecycle"
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// ErrInvalidTransition joriy holatdan so'ralgan holatga o'tib bo'lmaydi
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// accountStatusSQL muddati o'tgan to'xtatishni faol deb hisoblaydi.
+// users jadvali u aliasi bilan bo'lishi kerak.
+const accountStatusSQL = `CASE WHEN u.status = 'suspended' AND u.suspended_until <= CURRENT_TIMESTAMP THEN 'active' ELSE u.status END`
+
+func (u *UserRepo) GetAccountStatus(userId string) (*pb.AccountStatus, error) {
+	var status pb.AccountStatus
+	err := u.DB.QueryRow(`
+		SELECT
+			u.id,
+			`+accountStatusSQL+`,
+			COALESCE(u.status_reason, ''),
+			COALESCE(TO_CHAR(u.suspended_until AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'), ''),
+			COALESCE(TO_CHAR(u.status_changed_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'), ''),
+			COALESCE(TO_CHAR(u.deletion_scheduled_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'), '')
+		FROM
+			users u
+		WHERE
+			u.id::TEXT = $1
+	`, userId).Scan(&status.UserId, &status.Status, &status.Reason, &status.SuspendedUntil, &status.ChangedAt, &status.DeletionScheduledAt)
+
+	return &status, err
+}
+
+// SetStatus holatni lifecycle qoidalari bo'yicha o'zgartiradi va oldingi
+// holatni qaytaradi. until suspended uchun to'xtatish muddati (bo'sh bo'lsa
+// muddatsiz), pending_deletion uchun purge vaqti.
+func (u *UserRepo) SetStatus(userId, to, reason, until string) (*pb.AccountStatus, error) {
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var before pb.AccountStatus
+	err = tx.QueryRow(`
+		SELECT
+			u.id,
+			`+accountStatusSQL+`,
+			COALESCE(u.status_reason, '')
+		FROM
+			users u
+		WHERE
+			u.id::TEXT = $1
+		FOR UPDATE
+	`, userId).Scan(&before.UserId, &before.Status, &before.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lifecycle.CanTransition(before.Status, to) {
+		return &before, ErrInvalidTransition
+	}
+
+	_, err = tx.Exec(`
+		UPDATE
+			users
+		SET
+			status = $2,
+			status_reason = NULLIF($3, ''),
+			suspended_until = CASE WHEN $2 = 'suspended' THEN NULLIF($4, '')::TIMESTAMPTZ END,
+			deletion_scheduled_at = CASE WHEN $2 = 'pending_deletion' THEN NULLIF($4, '')::TIMESTAMPTZ END,
+			status_changed_at = CURRENT_TIMESTAMP,
+			deleted_at = CASE WHEN $2 = 'deleted' THEN DATE_PART('epoch', CURRENT_TIMESTAMP)::INT ELSE 0 END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE
+			id = $1
+	`, before.UserId, to, reason, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return &before, tx.Commit()
+}
+
+// PurgeUser foydalanuvchini anonimlashtiradi: profil, qurilmalar va
+// a'zoliklar o'chiriladi, users qatori esa login_events va audit_logs
+// havolalari uchun shaxsiy ma'lumotlarsiz qoladi.
+func (u *UserRepo) PurgeUser(userId string) error {
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := purgeUser(tx, userId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListDueDeletions grace muddati tugagan foydalanuvchilar
+func (u *UserRepo) ListDueDeletions(limit int) ([]string, error) {
+	rows, err := u.DB.Query(`
+		SELECT
+			id
+		FROM
+			users
+		WHERE
+			status = 'pending_deletion' AND deletion_scheduled_at <= CURRENT_TIMESTAMP
+		ORDER BY
+			deletion_scheduled_at
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+// PurgeIfDue foydalanuvchi hali ham pending_deletion va muddati o'tgan
+// bo'lsa anonimlashtiradi. Bu orada hisob tiklangan bo'lsa false.
+func (u *UserRepo) PurgeIfDue(userId string) (bool, error) {
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var due bool
+	err = tx.QueryRow(`
+		SELECT
+			status = 'pending_deletion' AND deletion_scheduled_at <= CURRENT_TIMESTAMP
+		FROM
+			users
+		WHERE
+			id = $1
+		FOR UPDATE
+	`, userId).Scan(&due)
+	if err != nil || !due {
+		return false, err
+	}
+
+	if err := purgeUser(tx, userId); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func purgeUser(tx *sql.Tx, userId string) error {
+	// Profil va users qatori o'chirilishidan oldin, identifikatorlar hali bor paytda
+	if err := redactHistory(tx, userId); err != nil {
+		return err
+	}
+
+	queries := []string{
+		`DELETE FROM login_challenges WHERE user_id::TEXT = $1`,
+		`DELETE FROM login_alerts WHERE user_id::TEXT = $1`,
+		`DELETE FROM phone_verifications WHERE user_id::TEXT = $1`,
+		`DELETE FROM known_devices WHERE user_id::TEXT = $1`,
+		`DELETE FROM restaurant_members WHERE user_id::TEXT = $1`,
+		`UPDATE api_keys SET created_by = NULL WHERE created_by::TEXT = $1`,
+		`UPDATE
+			sessions
+		SET
+			revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP),
+			device_name = NULL,
+			user_agent = NULL,
+			ip = NULL
+		WHERE
+			user_id::TEXT = $1`,
+		`DELETE FROM push_devices WHERE user_id::TEXT = $1`,
+		// Qabul qilingan versiyalar isbot sifatida qoladi, IP va qurilma o'chiriladi
+		`UPDATE consents SET ip = NULL, user_agent = NULL WHERE user_id::TEXT = $1`,
+		`DELETE FROM email_changes WHERE user_id::TEXT = $1`,
+		`DELETE FROM email_verifications WHERE user_id::TEXT = $1`,
+		`DELETE FROM username_history WHERE user_id::TEXT = $1`,
+		`DELETE FROM dining_preferences WHERE user_id::TEXT = $1`,
+		`DELETE FROM notification_preferences WHERE user_id::TEXT = $1`,
+		`DELETE FROM guest_sharing WHERE user_id::TEXT = $1`,
+		`DELETE FROM profile_history WHERE user_id::TEXT = $1`,
+		`DELETE FROM user_profiles WHERE user_id::TEXT = $1`,
+		// Tayyor arxivlar fayllarini export worker keyingi aylanishda o'chiradi
+		`UPDATE data_exports SET expires_at = CURRENT_TIMESTAMP WHERE user_id::TEXT = $1 AND status = 'ready'`,
+		`DELETE FROM data_exports WHERE user_id::TEXT = $1 AND status IN ('pending', 'processing', 'failed')`,
+		`UPDATE
+			users
+		SET
+			username = NULL,
+			email = NULL,
+			password = NULL,
+			email_verified = FALSE,
+			status = 'deleted',
+			status_reason = 'purged',
+			status_changed_at = CURRENT_TIMESTAMP,
+			suspended_until = NULL,
+			deletion_scheduled_at = NULL,
+			deleted_at = CASE WHEN deleted_at = 0 THEN DATE_PART('epoch', CURRENT_TIMESTAMP)::INT ELSE deleted_at END,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE
+			id::TEXT = $1`,
+	}
+
+	for _, query := range queries {
+		if _, err := tx.Exec(query, userId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redactHistory login_events va audit_logs dagi shaxsiy ma'lumotlarni
+// o'chiradi. Ikkala jadval ham append-only, trigger faqat shu tranzaksiyadagi
+// auth.purge_redact sozlamasi bilan shu maydonlarni o'zgartirishga ruxsat beradi.
+func redactHistory(tx *sql.Tx, userId string) error {
+	if _, err := tx.Exec(`SET LOCAL auth.purge_redact = 'on'`); err != nil {
+		return err
+	}
+
+	// Muvaffaqiyatsiz urinishlarda user_id bo'lmasligi mumkin, ular identifikator bo'yicha topiladi
+	_, err := tx.Exec(`
+		UPDATE
+			login_events le
+		SET
+			identifier = NULL,
+			ip = NULL,
+			user_agent = NULL
+		WHERE
+			(le.identifier IS NOT NULL OR le.ip IS NOT NULL OR le.user_agent IS NOT NULL)
+			AND (
+				le.user_id::TEXT = $1
+				OR LOWER(le.identifier) IN (
+					SELECT LOWER(v) FROM users u, UNNEST(ARRAY[u.username, u.email]) v WHERE u.id::TEXT = $1 AND v IS NOT NULL
+					UNION
+					SELECT phone_number FROM user_profiles WHERE user_id::TEXT = $1 AND phone_number IS NOT NULL AND phone_number <> ''
+				)
+			)
+	`, userId)
+	if err != nil {
+		return err
+	}
+
+	// Yozuv hashi payload_hash digestidan hisoblanadi, shuning uchun redaction
+	// dan keyin ham tekshiriladi. payload_hash siz eski yozuvlar o'zgartirilmaydi.
+	_, err = tx.Exec(`
+		UPDATE
+			audit_logs
+		SET
+			before = `+redactJSONSQL("before")+`,
+			after = `+redactJSONSQL("after")+`,
+			redacted_at = CURRENT_TIMESTAMP
+		WHERE
+			target_type = 'user' AND target_id = $1 AND redacted_at IS NULL AND payload_hash IS NOT NULL
+	`, userId, pq.Array(audit.PersonalFields), audit.Redacted)
+	return err
+}
+
+// redactJSONSQL JSONB obyektdagi $2 kalitlarining qiymatini $3 bilan almashtiradi
+func redactJSONSQL(column string) string {
+	return `CASE WHEN jsonb_typeof(` + column + `) = 'object' THEN COALESCE((
+				SELECT jsonb_object_agg(key, CASE WHEN key = ANY($2) THEN TO_JSONB($3::TEXT) ELSE value END)
+				FROM jsonb_each(` + column + `)
+			), ` + column + `) ELSE ` + column + ` END`
+}
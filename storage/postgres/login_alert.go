@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+)
+
+// MaxChallengeAttempts noto'g'ri kod kiritishlar soni chegarasi
+const MaxChallengeAttempts = 5
+
+// Login challenge turlari. Challenge faqat o'z endpointida ishlatiladi.
+const (
+	ChallengeStepUp  = "step_up"
+	ChallengeConsent = "consent"
+)
+
+type LoginAlertRepo struct {
+	DB *sql.DB
+}
+
+func NewLoginAlertRepo(db *sql.DB) *LoginAlertRepo {
+	return &LoginAlertRepo{DB: db}
+}
+
+type LoginAlert struct {
+	UserId      string
+	SessionId   string
+	Fingerprint string
+}
+
+type LoginChallenge struct {
+	Id         string
+	UserId     string
+	DeviceName string
+}
+
+func (l *LoginAlertRepo) CreateLoginAlert(alert *LoginAlert, tokenHash string, ttl time.Duration) error {
+	_, err := l.DB.Exec(`
+		INSERT INTO login_alerts (
+			user_id,
+			session_id,
+			fingerprint,
+			token_hash,
+			expires_at
+		)
+		VALUES (
+			$1,
+			NULLIF($2, '')::UUID,
+			$3,
+			$4,
+			CURRENT_TIMESTAMP + $5 * INTERVAL '1 second'
+		)
+	`, alert.UserId, alert.SessionId, alert.Fingerprint, tokenHash, int64(ttl.Seconds()))
+
+	return err
+}
+
+// UseLoginAlert havolani bir martalik ishlatadi. Muddati o'tgan yoki
+// ishlatilgan havola uchun sql.ErrNoRows qaytaradi.
+func (l *LoginAlertRepo) UseLoginAlert(tokenHash string) (*LoginAlert, error) {
+	var alert LoginAlert
+	err := l.DB.QueryRow(`
+		UPDATE
+			login_alerts
+		SET
+			used_at = CURRENT_TIMESTAMP
+		WHERE
+			token_hash = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		RETURNING
+			user_id,
+			COALESCE(session_id::TEXT, ''),
+			fingerprint
+	`, tokenHash).Scan(&alert.UserId, &alert.SessionId, &alert.Fingerprint)
+
+	return &alert, err
+}
+
+func (l *LoginAlertRepo) CreateLoginChallenge(purpose, userId, deviceName, codeHash string, ttl time.Duration) (string, error) {
+	var id string
+	err := l.DB.QueryRow(`
+		INSERT INTO login_challenges (
+			user_id,
+			device_name,
+			code_hash,
+			expires_at,
+			purpose
+		)
+		VALUES (
+			$1,
+			LEFT($2, 100),
+			$3,
+			CURRENT_TIMESTAMP + $4 * INTERVAL '1 second',
+			$5
+		)
+		RETURNING
+			id
+	`, userId, deviceName, codeHash, int64(ttl.Seconds()), purpose).Scan(&id)
+
+	return id, err
+}
+
+// VerifyLoginChallenge kodni tekshiradi. Har bir urinish sanaladi va
+// MaxChallengeAttempts dan keyin to'g'ri kod ham qabul qilinmaydi.
+func (l *LoginAlertRepo) VerifyLoginChallenge(purpose, id, codeHash string) (*LoginChallenge, error) {
+	var challenge LoginChallenge
+	var matched bool
+	err := l.DB.QueryRow(`
+		UPDATE
+			login_challenges
+		SET
+			attempts = attempts + 1,
+			used_at = CASE WHEN code_hash = $2 THEN CURRENT_TIMESTAMP END
+		WHERE
+			id::TEXT = $1 AND purpose = $4 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP AND attempts < $3
+		RETURNING
+			id,
+			user_id,
+			COALESCE(device_name, ''),
+			code_hash = $2
+	`, id, codeHash, MaxChallengeAttempts, purpose).Scan(&challenge.Id, &challenge.UserId, &challenge.DeviceName, &matched)
+	if err != nil {
+		return nil, err
+	}
+	if !matched {
+		return nil, sql.ErrNoRows
+	}
+
+	return &challenge, nil
+}
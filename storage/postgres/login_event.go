@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+	"fmt"
+)
+
+const (
+	LoginMethodPassword = "password"
+	LoginMethodOTP      = "otp"
+	LoginMethodSocial   = "social"
+)
+
+const (
+	LoginFailureUserNotFound    = "user_not_found"
+	LoginFailureInvalidPassword = "invalid_password"
+	LoginFailureInvalidCode     = "invalid_code"
+	LoginFailureInternal        = "internal_error"
+)
+
+type LoginEventRepo struct {
+	DB *sql.DB
+}
+
+func NewLoginEventRepo(db *sql.DB) *LoginEventRepo {
+	return &LoginEventRepo{DB: db}
+}
+
+// CreateLoginEvent identifier va user_agent ustun uzunligiga qisqartiriladi,
+// aks holda uzun qiymatli urinish yozilmay qoladi
+func (l *LoginEventRepo) CreateLoginEvent(event *pb.LoginEvent) error {
+	_, err := l.DB.Exec(`
+		INSERT INTO login_events (
+			user_id,
+			identifier,
+			success,
+			ip,
+			user_agent,
+			method,
+			failure_reason
+		)
+		VALUES (
+			NULLIF($1, '')::UUID,
+			LEFT($2, 100),
+			$3,
+			LEFT($4, 45),
+			LEFT($5, 255),
+			$6,
+			NULLIF($7, '')
+		)
+	`, event.UserId, event.Identifier, event.Success, event.Ip, event.UserAgent, event.Method, event.FailureReason)
+
+	return err
+}
+
+// ListLoginEvents bo'sh bo'lmagan filtrlar bo'yicha eng yangi hodisalarni qaytaradi
+func (l *LoginEventRepo) ListLoginEvents(in *pb.ListLoginEventsRequest) (*pb.ListLoginEventsResponse, error) {
+	f := &filter{}
+	f.add("user_id::TEXT = $%d", in.UserId)
+	f.add("ip = $%d", in.Ip)
+	f.add("created_at >= $%d::TIMESTAMPTZ", in.From)
+	f.add("created_at < $%d::TIMESTAMPTZ", in.To)
+
+	resp := &pb.ListLoginEventsResponse{}
+	err := l.DB.QueryRow(`
+		SELECT
+			COUNT(*)
+		FROM
+			login_events
+		`+f.where(), f.args...).Scan(&resp.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := pagination(in.Limit, in.Offset)
+	query := fmt.Sprintf(`
+		SELECT
+			id,
+			COALESCE(user_id::TEXT, ''),
+			COALESCE(identifier, ''),
+			success,
+			COALESCE(ip, ''),
+			COALESCE(user_agent, ''),
+			method,
+			COALESCE(failure_reason, ''),
+			created_at
+		FROM
+			login_events
+		%s
+		ORDER BY
+			created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, f.where(), f.arg(limit), f.arg(offset))
+
+	rows, err := l.DB.Query(query, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event pb.LoginEvent
+		err := rows.Scan(&event.Id, &event.UserId, &event.Identifier, &event.Success, &event.Ip,
+			&event.UserAgent, &event.Method, &event.FailureReason, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		resp.Events = append(resp.Events, &event)
+	}
+
+	return resp, rows.Err()
+}
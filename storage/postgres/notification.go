@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"auth-service/notify"
+)
+
+// GetNotificationPreferences saqlangan sozlamalarni standart qiymatlar bilan
+// to'ldirib qaytaradi
+func (u *UserRepo) GetNotificationPreferences(userId string) (*pb.NotificationPreferences, error) {
+	rows, err := u.DB.Query(`
+		SELECT
+			category,
+			channel,
+			enabled,
+			TO_CHAR(updated_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')
+		FROM
+			notification_preferences
+		WHERE
+			user_id::TEXT = $1
+		ORDER BY
+			updated_at
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prefs := pb.NotificationPreferences{UserId: userId}
+	var stored []*pb.NotificationPreference
+	for rows.Next() {
+		var p pb.NotificationPreference
+		if err := rows.Scan(&p.Category, &p.Channel, &p.Enabled, &prefs.UpdatedAt); err != nil {
+			return nil, err
+		}
+		stored = append(stored, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	prefs.Preferences = notify.Resolve(stored)
+	return &prefs, nil
+}
+
+// SetNotificationPreferences berilgan kategoriya va kanallarni yozadi,
+// qolganlari o'zgarmaydi
+func (u *UserRepo) SetNotificationPreferences(userId string, prefs []*pb.NotificationPreference) error {
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, p := range prefs {
+		_, err := tx.Exec(`
+			INSERT INTO notification_preferences (
+				user_id,
+				category,
+				channel,
+				enabled
+			)
+			VALUES (
+				$1,
+				$2,
+				$3,
+				$4
+			)
+			ON CONFLICT (user_id, category, channel) DO UPDATE SET
+				enabled = EXCLUDED.enabled,
+				updated_at = CURRENT_TIMESTAMP
+		`, userId, p.Category, p.Channel, p.Enabled)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ResetNotificationPreferences standart qiymatlarga qaytaradi
+func (u *UserRepo) ResetNotificationPreferences(userId string) error {
+	_, err := u.DB.Exec(`
+		DELETE FROM
+			notification_preferences
+		WHERE
+			user_id::TEXT = $1
+	`, userId)
+
+	return err
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrPhoneTaken raqam boshqa hisobda tasdiqlangan
+	ErrPhoneTaken = errors.New("phone number is verified by another account")
+	// ErrPhoneChanged kod yuborilgandan keyin profil raqami o'zgargan
+	ErrPhoneChanged = errors.New("phone number has changed since the code was sent")
+)
+
+// CreatePhoneVerification oldingi ishlatilmagan kodlarni bekor qilib yangi
+// kodni saqlaydi
+func (u *UserRepo) CreatePhoneVerification(userId, phoneNumber, codeHash string, ttl time.Duration) (string, error) {
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		UPDATE
+			phone_verifications
+		SET
+			used_at = CURRENT_TIMESTAMP
+		WHERE
+			user_id::TEXT = $1 AND used_at IS NULL
+	`, userId)
+	if err != nil {
+		return "", err
+	}
+
+	var id string
+	err = tx.QueryRow(`
+		INSERT INTO phone_verifications (
+			user_id,
+			phone_number,
+			code_hash,
+			expires_at
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			CURRENT_TIMESTAMP + $4 * INTERVAL '1 second'
+		)
+		RETURNING
+			id
+	`, userId, phoneNumber, codeHash, int64(ttl.Seconds())).Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit()
+}
+
+// VerifyPhone kodni tekshirib profil raqamini tasdiqlangan deb belgilaydi.
+// Noto'g'ri, eskirgan yoki MaxChallengeAttempts dan oshgan kod uchun
+// sql.ErrNoRows.
+func (u *UserRepo) VerifyPhone(userId, id, codeHash string) (string, error) {
+	var phoneNumber string
+	var matched bool
+	err := u.DB.QueryRow(`
+		UPDATE
+			phone_verifications
+		SET
+			attempts = attempts + 1,
+			used_at = CASE WHEN code_hash = $3 THEN CURRENT_TIMESTAMP END
+		WHERE
+			id::TEXT = $1 AND user_id::TEXT = $2 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP AND attempts < $4
+		RETURNING
+			phone_number,
+			code_hash = $3
+	`, id, userId, codeHash, MaxChallengeAttempts).Scan(&phoneNumber, &matched)
+	if err != nil {
+		return "", err
+	}
+	if !matched {
+		return "", sql.ErrNoRows
+	}
+
+	res, err := u.DB.Exec(`
+		UPDATE
+			user_profiles
+		SET
+			phone_verified = TRUE,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE
+			user_id::TEXT = $1 AND phone_number = $2
+	`, userId, phoneNumber)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return "", ErrPhoneTaken
+	}
+	if err != nil {
+		return "", err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if n == 0 {
+		return "", ErrPhoneChanged
+	}
+
+	return phoneNumber, nil
+}
+
+// PhoneVerifiedElsewhere raqam boshqa hisobda tasdiqlanganini tekshiradi
+func (u *UserRepo) PhoneVerifiedElsewhere(userId, phoneNumber string) (bool, error) {
+	var exists bool
+	err := u.DB.QueryRow(`
+		SELECT
+			EXISTS (
+				SELECT
+					1
+				FROM
+					user_profiles
+				WHERE
+					phone_number = $2 AND phone_verified AND user_id::TEXT <> $1
+			)
+	`, userId, phoneNumber).Scan(&exists)
+
+	return exists, err
+}
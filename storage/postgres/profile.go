@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"auth-service/profile"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrVersionConflict profil klient ko'rgan versiyadan keyin o'zgargan
+var ErrVersionConflict = errors.New("profile was modified by another request")
+
+// profileColumns maydon -> SET ifodasi. Bo'sh qiymat NULL bo'ladi; telefon
+// o'zgarsa tasdiqlanganlik belgisi tushadi.
+var profileColumns = map[string]string{
+	profile.FieldFullname:    "fullname = $%[1]d",
+	profile.FieldDateOfBirth: "date_of_birth = NULLIF($%[1]d, '')::DATE",
+	profile.FieldPhoneNumber: "phone_number = NULLIF($%[1]d, ''), phone_verified = phone_verified AND phone_number IS NOT DISTINCT FROM NULLIF($%[1]d, '')",
+	profile.FieldAddress:     "address = NULLIF($%[1]d, '')",
+	profile.FieldLocale:      "locale = NULLIF($%[1]d, '')",
+	profile.FieldTimezone:    "timezone = NULLIF($%[1]d, '')",
+}
+
+// UpdateProfileFields faqat berilgan maydonlarni yangilaydi, yangi versiyani
+// tarixga yozadi va qaytaradi. Qiymatlar profile.Normalize dan o'tgan
+// bo'lishi kerak. version 0 bo'lmasa joriy versiya bilan mos kelishi shart.
+func (u *UserRepo) UpdateProfileFields(userId string, fields map[string]string, version int64, change profile.Change) (int64, error) {
+	f := &filter{}
+	var set []string
+	for _, field := range profile.Fields {
+		value, ok := fields[field]
+		if !ok {
+			continue
+		}
+		set = append(set, fmt.Sprintf(profileColumns[field], f.arg(value)))
+	}
+	if len(set) != len(fields) {
+		return 0, profile.ErrUnknownField
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE
+			user_profiles
+		SET
+			%s
+			version = version + 1,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE
+			user_id::TEXT = $%d AND ($%[3]d::BIGINT = 0 OR version = $%[3]d::BIGINT)
+		RETURNING
+			version
+	`, strings.Join(append(set, ""), ",\n\t\t\t"), f.arg(userId), f.arg(version))
+
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var newVersion int64
+	err = tx.QueryRow(query, f.args...).Scan(&newVersion)
+	if err == sql.ErrNoRows && version != 0 {
+		// Profil bormi yoki versiya eskirganmi
+		var current int64
+		if err := tx.QueryRow(`SELECT version FROM user_profiles WHERE user_id::TEXT = $1`, userId).Scan(&current); err != nil {
+			return 0, err
+		}
+		return current, ErrVersionConflict
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if err := recordProfileVersion(tx, userId, change); err != nil {
+		return 0, err
+	}
+	return newVersion, tx.Commit()
+}
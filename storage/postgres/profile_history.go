@@ -0,0 +1,173 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"auth-service/profile"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// profileVersionColumns ProfileVersion uchun ustunlar, scanProfileVersion
+// bilan bir xil tartibda
+const profileVersionColumns = `
+			user_id,
+			version,
+			COALESCE(username, ''),
+			COALESCE(fullname, ''),
+			COALESCE(TO_CHAR(date_of_birth, 'YYYY-MM-DD'), ''),
+			COALESCE(phone_number, ''),
+			COALESCE(address, ''),
+			COALESCE(avatar_url, ''),
+			changed_fields,
+			COALESCE(changed_by, ''),
+			channel,
+			COALESCE(reverted_from, 0),
+			TO_CHAR(changed_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+			COALESCE(locale, ''),
+			COALESCE(timezone, '')`
+
+func scanProfileVersion(row rowScanner) (*pb.ProfileVersion, error) {
+	var v pb.ProfileVersion
+	err := row.Scan(&v.UserId, &v.Version, &v.Username, &v.Fullname, &v.DateOfBirth, &v.PhoneNumber, &v.Address,
+		&v.AvatarUrl, pq.Array(&v.ChangedFields), &v.ChangedBy, &v.Channel, &v.RevertedFrom, &v.ChangedAt,
+		&v.Locale, &v.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// recordProfileVersion profilning joriy holatini tarixga yozadi. O'zgarish
+// bilan bitta tranzaksiyada, UPDATE dan keyin chaqiriladi. O'zgargan
+// maydonlar oldingi versiya bilan solishtirib topiladi.
+func recordProfileVersion(tx *sql.Tx, userId string, change profile.Change) error {
+	_, err := tx.Exec(`
+		INSERT INTO profile_history (
+			user_id,
+			version,
+			username,
+			fullname,
+			date_of_birth,
+			phone_number,
+			address,
+			avatar_url,
+			locale,
+			timezone,
+			changed_fields,
+			changed_by,
+			channel,
+			reverted_from
+		)
+		SELECT
+			p.user_id,
+			p.version,
+			p.username,
+			p.fullname,
+			p.date_of_birth,
+			p.phone_number,
+			p.address,
+			p.avatar_url,
+			p.locale,
+			p.timezone,
+			ARRAY_REMOVE(ARRAY[
+				CASE WHEN h.user_id IS NULL OR p.username IS DISTINCT FROM h.username THEN 'username' END,
+				CASE WHEN h.user_id IS NULL OR p.fullname IS DISTINCT FROM h.fullname THEN 'fullname' END,
+				CASE WHEN h.user_id IS NULL OR p.date_of_birth IS DISTINCT FROM h.date_of_birth THEN 'date_of_birth' END,
+				CASE WHEN h.user_id IS NULL OR p.phone_number IS DISTINCT FROM h.phone_number THEN 'phone_number' END,
+				CASE WHEN h.user_id IS NULL OR p.address IS DISTINCT FROM h.address THEN 'address' END,
+				CASE WHEN h.user_id IS NULL OR p.avatar_url IS DISTINCT FROM h.avatar_url THEN 'avatar_url' END,
+				CASE WHEN h.user_id IS NULL OR p.locale IS DISTINCT FROM h.locale THEN 'locale' END,
+				CASE WHEN h.user_id IS NULL OR p.timezone IS DISTINCT FROM h.timezone THEN 'timezone' END
+			], NULL),
+			NULLIF($2, ''),
+			$3,
+			NULLIF($4::BIGINT, 0)
+		FROM
+			user_profiles p
+		LEFT JOIN LATERAL (
+			SELECT
+				*
+			FROM
+				profile_history
+			WHERE
+				user_id = p.user_id
+			ORDER BY
+				version DESC
+			LIMIT 1
+		) h ON TRUE
+		WHERE
+			p.user_id::TEXT = $1
+	`, userId, change.ActorId, change.Channel, change.RevertedFrom)
+
+	return err
+}
+
+// ListProfileHistory profil versiyalari, yangisi birinchi
+func (u *UserRepo) ListProfileHistory(in *pb.ListProfileHistoryRequest) (*pb.ListProfileHistoryResponse, error) {
+	resp := &pb.ListProfileHistoryResponse{}
+	err := u.DB.QueryRow(`
+		SELECT
+			COUNT(*)
+		FROM
+			profile_history
+		WHERE
+			user_id::TEXT = $1
+	`, in.UserId).Scan(&resp.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := pagination(in.Limit, in.Offset)
+	rows, err := u.DB.Query(`
+		SELECT`+profileVersionColumns+`
+		FROM
+			profile_history
+		WHERE
+			user_id::TEXT = $1
+		ORDER BY
+			version DESC
+		LIMIT $2 OFFSET $3
+	`, in.UserId, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp.Versions = []*pb.ProfileVersion{}
+	for rows.Next() {
+		v, err := scanProfileVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		resp.Versions = append(resp.Versions, v)
+	}
+
+	return resp, rows.Err()
+}
+
+// GetProfileAsOf at vaqtida amalda bo'lgan versiya. Profil o'sha paytda
+// hali bo'lmagan bo'lsa sql.ErrNoRows.
+func (u *UserRepo) GetProfileAsOf(userId, at string) (*pb.ProfileVersion, error) {
+	return scanProfileVersion(u.DB.QueryRow(`
+		SELECT`+profileVersionColumns+`
+		FROM
+			profile_history
+		WHERE
+			user_id::TEXT = $1 AND changed_at <= $2::TIMESTAMPTZ
+		ORDER BY
+			changed_at DESC, version DESC
+		LIMIT 1
+	`, userId, at))
+}
+
+// GetProfileVersion aniq bir versiya
+func (u *UserRepo) GetProfileVersion(userId string, version int64) (*pb.ProfileVersion, error) {
+	return scanProfileVersion(u.DB.QueryRow(`
+		SELECT`+profileVersionColumns+`
+		FROM
+			profile_history
+		WHERE
+			user_id::TEXT = $1 AND version = $2
+	`, userId, version))
+}
@@ -0,0 +1,158 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+)
+
+const pushDeviceColumns = `
+			id,
+			user_id,
+			session_id,
+			provider,
+			token,
+			TO_CHAR(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+			TO_CHAR(updated_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')`
+
+func scanPushDevice(row rowScanner) (*pb.PushDevice, error) {
+	var d pb.PushDevice
+	err := row.Scan(&d.Id, &d.UserId, &d.SessionId, &d.Provider, &d.Token, &d.CreatedAt, &d.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// RegisterPushDevice tokenni sessiyaga bog'laydi. Token boshqa sessiya yoki
+// foydalanuvchida bo'lsa (ilova qayta o'rnatilgan) shu sessiyaga o'tkaziladi.
+func (s *SessionRepo) RegisterPushDevice(userId, sessionId, provider, token string) (*pb.PushDevice, error) {
+	return scanPushDevice(s.DB.QueryRow(`
+		INSERT INTO push_devices (
+			user_id,
+			session_id,
+			provider,
+			token
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			$4
+		)
+		ON CONFLICT (token) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			session_id = EXCLUDED.session_id,
+			provider = EXCLUDED.provider,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING`+pushDeviceColumns+`
+	`, userId, sessionId, provider, token))
+}
+
+// RefreshPushDevice provayder bergan yangi tokenni yozadi va qurilmani joriy
+// sessiyaga bog'laydi. Qurilma topilmasa sql.ErrNoRows.
+func (s *SessionRepo) RefreshPushDevice(userId, sessionId, id, token string) (*pb.PushDevice, error) {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		DELETE FROM
+			push_devices
+		WHERE
+			token = $1 AND id::TEXT <> $2
+	`, token, id)
+	if err != nil {
+		return nil, err
+	}
+
+	device, err := scanPushDevice(tx.QueryRow(`
+		UPDATE
+			push_devices
+		SET
+			token = $3,
+			session_id = $4,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE
+			id::TEXT = $1 AND user_id::TEXT = $2
+		RETURNING`+pushDeviceColumns+`
+	`, id, userId, token, sessionId))
+	if err != nil {
+		return nil, err
+	}
+
+	return device, tx.Commit()
+}
+
+// GetPushDevice foydalanuvchining qurilmasi, topilmasa sql.ErrNoRows
+func (s *SessionRepo) GetPushDevice(userId, id string) (*pb.PushDevice, error) {
+	return scanPushDevice(s.DB.QueryRow(`
+		SELECT`+pushDeviceColumns+`
+		FROM
+			push_devices
+		WHERE
+			id::TEXT = $1 AND user_id::TEXT = $2
+	`, id, userId))
+}
+
+// RemovePushDevice qurilma topilmasa sql.ErrNoRows qaytaradi
+func (s *SessionRepo) RemovePushDevice(userId, id string) error {
+	res, err := s.DB.Exec(`
+		DELETE FROM
+			push_devices
+		WHERE
+			id::TEXT = $1 AND user_id::TEXT = $2
+	`, id, userId)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ListPushDevices faol sessiyalardagi qurilmalar. provider bo'sh bo'lsa
+// hammasi.
+func (s *SessionRepo) ListPushDevices(userId, provider string) ([]*pb.PushDevice, error) {
+	rows, err := s.DB.Query(`
+		SELECT`+pushDeviceColumns+`
+		FROM
+			push_devices d
+		WHERE
+			user_id::TEXT = $1
+			AND ($2 = '' OR provider = $2)
+			AND EXISTS (
+				SELECT
+					1
+				FROM
+					sessions s
+				WHERE
+					s.id = d.session_id AND s.revoked_at IS NULL
+			)
+		ORDER BY
+			updated_at DESC
+	`, userId, provider)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	devices := []*pb.PushDevice{}
+	for rows.Next() {
+		d, err := scanPushDevice(rows)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, d)
+	}
+
+	return devices, rows.Err()
+}
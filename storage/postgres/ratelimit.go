@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"auth-service/ratelimit"
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimitRepo bir nechta instance bir xil limitni bo'lishishi uchun
+// ratelimit.Store ni Postgresda amalga oshiradi
+type RateLimitRepo struct {
+	DB        *sql.DB
+	lastSweep atomic.Int64
+}
+
+func NewRateLimitRepo(db *sql.DB) *RateLimitRepo {
+	return &RateLimitRepo{DB: db}
+}
+
+func (r *RateLimitRepo) Update(ctx context.Context, key string, ttl time.Duration, fn func(ratelimit.State) (ratelimit.State, ratelimit.Result)) (ratelimit.Result, error) {
+	r.deleteExpired(ctx)
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO rate_limits (
+			key
+		)
+		VALUES (
+			$1
+		)
+		ON CONFLICT (key) DO NOTHING
+	`, key)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	var state ratelimit.State
+	var windowStart, updatedAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `
+		SELECT
+			tokens,
+			window_start,
+			count,
+			prev_count,
+			updated_at
+		FROM
+			rate_limits
+		WHERE
+			key = $1
+		FOR UPDATE
+	`, key).Scan(&state.Tokens, &windowStart, &state.Count, &state.PrevCount, &updatedAt)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	state.WindowStart = windowStart.Time
+	state.UpdatedAt = updatedAt.Time
+
+	state, res := fn(state)
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE
+			rate_limits
+		SET
+			tokens = $1,
+			window_start = $2,
+			count = $3,
+			prev_count = $4,
+			updated_at = $5,
+			expires_at = $6
+		WHERE
+			key = $7
+	`, state.Tokens, nullTime(state.WindowStart), state.Count, state.PrevCount, nullTime(state.UpdatedAt), time.Now().Add(ttl), key)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	return res, tx.Commit()
+}
+
+// deleteExpired eskirgan kalitlarni daqiqasiga ko'pi bilan bir marta tozalaydi
+func (r *RateLimitRepo) deleteExpired(ctx context.Context) {
+	now := time.Now().Unix()
+	last := r.lastSweep.Load()
+	if now-last < 60 || !r.lastSweep.CompareAndSwap(last, now) {
+		return
+	}
+
+	r.DB.ExecContext(ctx, `
+		DELETE FROM
+			rate_limits
+		WHERE
+			expires_at < CURRENT_TIMESTAMP
+	`)
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+)
+
+const (
+	RestaurantRoleOwner = "owner"
+	RestaurantRoleStaff = "staff"
+)
+
+type RestaurantRepo struct {
+	DB *sql.DB
+}
+
+func NewRestaurantRepo(db *sql.DB) *RestaurantRepo {
+	return &RestaurantRepo{DB: db}
+}
+
+func (r *RestaurantRepo) SetMember(restaurantId, userId, role string) error {
+	_, err := r.DB.Exec(`
+		INSERT INTO restaurant_members (
+			restaurant_id,
+			user_id,
+			role
+		)
+		VALUES (
+			$1,
+			$2,
+			$3
+		)
+		ON CONFLICT (restaurant_id, user_id) DO UPDATE SET
+			role = EXCLUDED.role
+	`, restaurantId, userId, role)
+
+	return err
+}
+
+func (r *RestaurantRepo) RemoveMember(restaurantId, userId string) error {
+	_, err := r.DB.Exec(`
+		DELETE FROM
+			restaurant_members
+		WHERE
+			restaurant_id::TEXT = $1 AND user_id::TEXT = $2
+	`, restaurantId, userId)
+
+	return err
+}
+
+// MemberRole a'zo bo'lmasa bo'sh satr qaytaradi
+func (r *RestaurantRepo) MemberRole(restaurantId, userId string) (string, error) {
+	var role string
+	err := r.DB.QueryRow(`
+		SELECT
+			role
+		FROM
+			restaurant_members
+		WHERE
+			restaurant_id::TEXT = $1 AND user_id::TEXT = $2
+	`, restaurantId, userId).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return role, err
+}
+
+// ListMemberships foydalanuvchi a'zo bo'lgan restoranlar
+func (r *RestaurantRepo) ListMemberships(userId string) ([]*pb.RestaurantMembership, error) {
+	rows, err := r.DB.Query(`
+		SELECT
+			restaurant_id,
+			role
+		FROM
+			restaurant_members
+		WHERE
+			user_id::TEXT = $1
+		ORDER BY
+			restaurant_id
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var memberships []*pb.RestaurantMembership
+	for rows.Next() {
+		var m pb.RestaurantMembership
+		if err := rows.Scan(&m.RestaurantId, &m.Role); err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, &m)
+	}
+
+	return memberships, rows.Err()
+}
@@ -0,0 +1,221 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+	"time"
+)
+
+type SessionRepo struct {
+	DB *sql.DB
+}
+
+func NewSessionRepo(db *sql.DB) *SessionRepo {
+	return &SessionRepo{DB: db}
+}
+
+func (s *SessionRepo) CreateSession(userId, deviceName, userAgent, ip string) (string, error) {
+	var id string
+	err := s.DB.QueryRow(`
+		INSERT INTO sessions (
+			user_id,
+			device_name,
+			user_agent,
+			ip
+		)
+		VALUES (
+			$1,
+			NULLIF(LEFT($2, 100), ''),
+			NULLIF(LEFT($3, 255), ''),
+			$4
+		)
+		RETURNING
+			id
+	`, userId, deviceName, userAgent, ip).Scan(&id)
+
+	return id, err
+}
+
+// CreateImpersonationSession support xodimi uchun sessiya ochadi. U
+// foydalanuvchining qurilmalar ro'yxatida ttl tugaguncha ko'rinadi.
+func (s *SessionRepo) CreateImpersonationSession(userId, actorId, userAgent, ip string, ttl time.Duration) (string, error) {
+	var id string
+	err := s.DB.QueryRow(`
+		INSERT INTO sessions (
+			user_id,
+			device_name,
+			user_agent,
+			ip,
+			impersonator_id,
+			expires_at
+		)
+		VALUES (
+			$1,
+			'Support session',
+			NULLIF(LEFT($2, 255), ''),
+			$3,
+			$4,
+			CURRENT_TIMESTAMP + $5 * INTERVAL '1 second'
+		)
+		RETURNING
+			id
+	`, userId, userAgent, ip, actorId, int64(ttl.Seconds())).Scan(&id)
+
+	return id, err
+}
+
+func (s *SessionRepo) ListSessions(in *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	rows, err := s.DB.Query(`
+		SELECT
+			id,
+			COALESCE(device_name, ''),
+			COALESCE(user_agent, ''),
+			COALESCE(ip, ''),
+			created_at,
+			last_seen_at,
+			COALESCE(impersonator_id::TEXT, '')
+		FROM
+			sessions
+		WHERE
+			user_id = $1 AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > CURRENT_TIMESTAMP)
+		ORDER BY
+			last_seen_at DESC
+	`, in.UserId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := &pb.ListSessionsResponse{}
+	for rows.Next() {
+		var session pb.Session
+		err := rows.Scan(&session.Id, &session.DeviceName, &session.UserAgent, &session.Ip, &session.CreatedAt, &session.LastSeenAt, &session.ImpersonatedBy)
+		if err != nil {
+			return nil, err
+		}
+		session.Current = session.Id == in.CurrentSessionId
+		resp.Sessions = append(resp.Sessions, &session)
+	}
+
+	return resp, rows.Err()
+}
+
+// RevokeSession sessiya topilmasa sql.ErrNoRows qaytaradi. Sessiyaga
+// bog'langan push qurilmalar o'chiriladi.
+func (s *SessionRepo) RevokeSession(userId, sessionId string) error {
+	if !isUUID(sessionId) {
+		return sql.ErrNoRows
+	}
+
+	var n int64
+	err := s.DB.QueryRow(`
+		WITH revoked AS (
+			UPDATE
+				sessions
+			SET
+				revoked_at = CURRENT_TIMESTAMP
+			WHERE
+				id = $1::UUID AND user_id = $2 AND revoked_at IS NULL
+			RETURNING
+				id
+		), removed AS (
+			DELETE FROM
+				push_devices
+			WHERE
+				session_id IN (SELECT id FROM revoked)
+		)
+		SELECT
+			COUNT(*)
+		FROM
+			revoked
+	`, sessionId, userId).Scan(&n)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (s *SessionRepo) RevokeOtherSessions(userId, currentSessionId string) (int64, error) {
+	// Bo'sh yoki noto'g'ri id bilan hamma sessiyalar bekor qilinadi
+	current := sql.NullString{String: currentSessionId, Valid: isUUID(currentSessionId)}
+
+	var n int64
+	err := s.DB.QueryRow(`
+		WITH revoked AS (
+			UPDATE
+				sessions
+			SET
+				revoked_at = CURRENT_TIMESTAMP
+			WHERE
+				user_id = $1 AND id IS DISTINCT FROM $2::UUID AND revoked_at IS NULL
+			RETURNING
+				id
+		), removed AS (
+			DELETE FROM
+				push_devices
+			WHERE
+				session_id IN (SELECT id FROM revoked)
+		)
+		SELECT
+			COUNT(*)
+		FROM
+			revoked
+	`, userId, current).Scan(&n)
+
+	return n, err
+}
+
+// Touch sessiya faol bo'lsa last_seen_at ni yangilaydi va foydalanuvchi
+// hisobining holatini qaytaradi. Sessiya bekor qilingan yoki muddati tugagan bo'lsa bo'sh satr.
+func (s *SessionRepo) Touch(sessionId string) (string, error) {
+	if !isUUID(sessionId) {
+		return "", nil
+	}
+
+	var status string
+	err := s.DB.QueryRow(`
+		UPDATE
+			sessions s
+		SET
+			last_seen_at = CURRENT_TIMESTAMP
+		FROM
+			users u
+		WHERE
+			s.id = $1::UUID AND s.revoked_at IS NULL AND (s.expires_at IS NULL OR s.expires_at > CURRENT_TIMESTAMP)
+			AND u.id = s.user_id
+		RETURNING
+			`+accountStatusSQL+`
+	`, sessionId).Scan(&status)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+
+	return status, err
+}
+
+// OpenedWithin sessiya oxirgi within ichida ochilganini tekshiradi.
+// "Yaqinda kirgan" talab qilinadigan amallar uchun.
+func (s *SessionRepo) OpenedWithin(sessionId string, within time.Duration) (bool, error) {
+	if !isUUID(sessionId) {
+		return false, nil
+	}
+
+	var recent bool
+	err := s.DB.QueryRow(`
+		SELECT
+			created_at >= CURRENT_TIMESTAMP - MAKE_INTERVAL(secs => $2)
+		FROM
+			sessions
+		WHERE
+			id = $1::UUID AND revoked_at IS NULL
+	`, sessionId, within.Seconds()).Scan(&recent)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	return recent, err
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessions(t *testing.T) {
+	db, err := ConnectDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	session := NewSessionRepo(db)
+
+	userId := "fc27aae7-e777-45f1-9431-f00c31dfdea0"
+	current, err := session.CreateSession(userId, "iPhone", "Mozilla/5.0", "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := session.CreateSession(userId, "Tablet", "Mozilla/5.0", "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := session.ListSessions(&pb.ListSessionsRequest{UserId: userId, CurrentSessionId: current})
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(resp.Sessions), 2)
+
+	assert.NoError(t, session.RevokeSession(userId, other))
+
+	status, err := session.Touch(other)
+	assert.NoError(t, err)
+	assert.Empty(t, status)
+
+	_, err = session.RevokeOtherSessions(userId, current)
+	assert.NoError(t, err)
+
+	status, err = session.Touch(current)
+	assert.NoError(t, err)
+	assert.Equal(t, "active", status)
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	pb "auth-service/generated/auth_service"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// GetSharingSettings sozlama saqlanmagan bo'lsa hech narsa ulashilmaydi
+func (u *UserRepo) GetSharingSettings(userId string) (*pb.SharingSettings, error) {
+	settings := pb.SharingSettings{UserId: userId}
+	err := u.DB.QueryRow(`
+		SELECT
+			share_name,
+			share_phone,
+			share_allergies,
+			share_visit_history,
+			TO_CHAR(updated_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')
+		FROM
+			guest_sharing
+		WHERE
+			user_id::TEXT = $1
+	`, userId).Scan(&settings.ShareName, &settings.SharePhone, &settings.ShareAllergies, &settings.ShareVisitHistory, &settings.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return &settings, nil
+	}
+
+	return &settings, err
+}
+
+func (u *UserRepo) SetSharingSettings(settings *pb.SharingSettings) error {
+	_, err := u.DB.Exec(`
+		INSERT INTO guest_sharing (
+			user_id,
+			share_name,
+			share_phone,
+			share_allergies,
+			share_visit_history
+		)
+		VALUES (
+			$1,
+			$2,
+			$3,
+			$4,
+			$5
+		)
+		ON CONFLICT (user_id) DO UPDATE SET
+			share_name = EXCLUDED.share_name,
+			share_phone = EXCLUDED.share_phone,
+			share_allergies = EXCLUDED.share_allergies,
+			share_visit_history = EXCLUDED.share_visit_history,
+			updated_at = CURRENT_TIMESTAMP
+	`, settings.UserId, settings.ShareName, settings.SharePhone, settings.ShareAllergies, settings.ShareVisitHistory)
+
+	return err
+}
+
+// GetGuestProfile faqat diner ulashishga rozi bo'lgan maydonlarni qaytaradi.
+// Filtrlash SQL da qilinadi, ulashilmagan ma'lumot bazadan chiqmaydi.
+func (u *UserRepo) GetGuestProfile(restaurantId, userId string) (*pb.GuestProfile, error) {
+	guest := pb.GuestProfile{RestaurantId: restaurantId}
+	var shareName, sharePhone, shareAllergies bool
+	err := u.DB.QueryRow(`
+		SELECT
+			u.id,
+			COALESCE(s.share_name, FALSE),
+			COALESCE(s.share_phone, FALSE),
+			COALESCE(s.share_allergies, FALSE),
+			COALESCE(s.share_visit_history, FALSE),
+			CASE WHEN s.share_name THEN COALESCE(p.fullname, '') ELSE '' END,
+			CASE WHEN s.share_phone THEN COALESCE(p.phone_number, '') ELSE '' END,
+			CASE WHEN s.share_allergies THEN COALESCE(d.allergies, '{}') ELSE '{}' END,
+			CASE WHEN s.share_allergies THEN COALESCE(d.dietary_restrictions, '{}') ELSE '{}' END
+		FROM
+			users u
+		LEFT JOIN
+			guest_sharing s ON s.user_id = u.id
+		LEFT JOIN
+			user_profiles p ON p.user_id = u.id
+		LEFT JOIN
+			dining_preferences d ON d.user_id = u.id
+		WHERE
+			u.id::TEXT = $1 AND u.status <> 'deleted'
+	`, userId).Scan(&guest.UserId, &shareName, &sharePhone, &shareAllergies, &guest.VisitHistoryShared,
+		&guest.Fullname, &guest.PhoneNumber, pq.Array(&guest.Allergies), pq.Array(&guest.DietaryRestrictions))
+	if err != nil {
+		return nil, err
+	}
+
+	guest.SharedFields = []string{}
+	for _, f := range []struct {
+		shared bool
+		name   string
+	}{
+		{shareName, "name"},
+		{sharePhone, "phone"},
+		{shareAllergies, "allergies"},
+		{guest.VisitHistoryShared, "visit_history"},
+	} {
+		if f.shared {
+			guest.SharedFields = append(guest.SharedFields, f.name)
+		}
+	}
+
+	return &guest, nil
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"auth-service/auth/lifecycle"
+	pb "auth-service/generated/auth_service"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidFilter noto'g'ri qidiruv parametrlari uchun. Handlerlar uni
+// 400 / INVALID_ARGUMENT ga aylantiradi.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// userSorts sort_by qiymatidan ORDER BY ifodasi va cursor qiymatining turi
+var userSorts = map[string]struct{ expr, cast string }{
+	"created_at": {"u.created_at", "TIMESTAMP"},
+	"username":   {"LOWER(COALESCE(u.username, ''))", "TEXT"},
+	"email":      {"LOWER(COALESCE(u.email, ''))", "TEXT"},
+}
+
+type userCursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	Id    string `json:"id"`
+}
+
+// SearchUsers admin uchun foydalanuvchilarni qidiradi. Sahifalash
+// (sort qiymati, id) juftligi bo'yicha keyset usulida.
+func (u *UserRepo) SearchUsers(in *pb.SearchUsersRequest) (*pb.SearchUsersResponse, error) {
+	sortBy := in.SortBy
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+	sort, ok := userSorts[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("%w: sort_by must be created_at, username or email", ErrInvalidFilter)
+	}
+
+	order, cmp := "DESC", "<"
+	switch strings.ToLower(in.SortOrder) {
+	case "asc":
+		order, cmp = "ASC", ">"
+	case "", "desc":
+	default:
+		return nil, fmt.Errorf("%w: sort_order must be asc or desc", ErrInvalidFilter)
+	}
+
+	f := &filter{}
+	if q := strings.TrimSpace(in.Query); q != "" {
+		pattern := "%" + escapeLike(q) + "%"
+		f.conditions = append(f.conditions, fmt.Sprintf(
+			"(u.email ILIKE $%[1]d OR u.username ILIKE $%[1]d OR p.fullname ILIKE $%[1]d OR p.phone_number ILIKE $%[1]d OR p.fullname %% $%[2]d)",
+			f.arg(pattern), f.arg(q)))
+	}
+
+	if in.Status != "" && !lifecycle.Valid(in.Status) {
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidFilter, in.Status)
+	}
+	f.add(accountStatusSQL+" = $%d", in.Status)
+
+	for _, flag := range []string{in.EmailVerified, in.PhoneVerified} {
+		if flag != "" && flag != "true" && flag != "false" {
+			return nil, fmt.Errorf("%w: verification flags must be true or false", ErrInvalidFilter)
+		}
+	}
+
+	f.add("u.role = $%d", in.Role)
+	f.add("u.created_at >= $%d::TIMESTAMPTZ", in.CreatedFrom)
+	f.add("u.created_at < $%d::TIMESTAMPTZ", in.CreatedTo)
+	f.add("u.email_verified = $%d::BOOLEAN", in.EmailVerified)
+	f.add("COALESCE(p.phone_verified, FALSE) = $%d::BOOLEAN", in.PhoneVerified)
+
+	if in.Cursor != "" {
+		c, err := decodeUserCursor(in.Cursor)
+		if err != nil || c.Sort != sortBy {
+			return nil, fmt.Errorf("%w: cursor is invalid or belongs to another sort", ErrInvalidFilter)
+		}
+		f.conditions = append(f.conditions, fmt.Sprintf("(%s, u.id) %s ($%d::%s, $%d::UUID)",
+			sort.expr, cmp, f.arg(c.Value), sort.cast, f.arg(c.Id)))
+	}
+
+	limit, _ := pagination(in.Limit, 0)
+	query := fmt.Sprintf(`
+		SELECT
+			u.id,
+			COALESCE(u.username, ''),
+			COALESCE(u.email, ''),
+			COALESCE(p.fullname, ''),
+			COALESCE(p.phone_number, ''),
+			u.role,
+			`+accountStatusSQL+`,
+			u.email_verified,
+			COALESCE(p.phone_verified, FALSE),
+			u.created_at,
+			(%[1]s)::TEXT
+		FROM
+			users u
+		LEFT JOIN
+			user_profiles p ON p.user_id = u.id
+		%[2]s
+		ORDER BY
+			%[1]s %[3]s, u.id %[3]s
+		LIMIT $%[4]d
+	`, sort.expr, f.where(), order, f.arg(limit+1))
+
+	rows, err := u.DB.Query(query, f.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	resp := &pb.SearchUsersResponse{}
+	var last userCursor
+	for rows.Next() {
+		var user pb.UserSummary
+		var sortValue string
+		err := rows.Scan(&user.UserId, &user.Username, &user.Email, &user.Fullname, &user.PhoneNumber, &user.Role,
+			&user.Status, &user.EmailVerified, &user.PhoneVerified, &user.CreatedAt, &sortValue)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(resp.Users) == int(limit) {
+			resp.NextCursor = encodeUserCursor(last)
+			break
+		}
+		resp.Users = append(resp.Users, &user)
+		last = userCursor{Sort: sortBy, Value: sortValue, Id: user.UserId}
+	}
+
+	return resp, rows.Err()
+}
+
+// GetUserSummary bitta foydalanuvchining qisqa ma'lumoti
+func (u *UserRepo) GetUserSummary(userId string) (*pb.UserSummary, error) {
+	var user pb.UserSummary
+	err := u.DB.QueryRow(`
+		SELECT
+			u.id,
+			COALESCE(u.username, ''),
+			COALESCE(u.email, ''),
+			COALESCE(p.fullname, ''),
+			COALESCE(p.phone_number, ''),
+			u.role,
+			`+accountStatusSQL+`,
+			u.email_verified,
+			COALESCE(p.phone_verified, FALSE),
+			u.created_at
+		FROM
+			users u
+		LEFT JOIN
+			user_profiles p ON p.user_id = u.id
+		WHERE
+			u.id::TEXT = $1
+	`, userId).Scan(&user.UserId, &user.Username, &user.Email, &user.Fullname, &user.PhoneNumber, &user.Role,
+		&user.Status, &user.EmailVerified, &user.PhoneVerified, &user.CreatedAt)
+
+	return &user, err
+}
+
+func encodeUserCursor(c userCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeUserCursor(s string) (userCursor, error) {
+	var c userCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	return c, json.Unmarshal(b, &c)
+}
+
+// escapeLike LIKE maxsus belgilarini oddiy belgi sifatida qidirish uchun
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
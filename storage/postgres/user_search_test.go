@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserCursor(t *testing.T) {
+	c := userCursor{Sort: "created_at", Value: "2024-05-01 10:00:00.123456", Id: "7c9e6679-7425-40de-944b-e07fc1f90ae7"}
+
+	decoded, err := decodeUserCursor(encodeUserCursor(c))
+	assert.NoError(t, err)
+	assert.Equal(t, c, decoded)
+
+	_, err = decodeUserCursor("not a cursor!")
+	assert.Error(t, err)
+}
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, `100\%\_off\\`, escapeLike(`100%_off\`))
+	assert.Equal(t, "john", escapeLike("john"))
+}
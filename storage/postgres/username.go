@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"auth-service/profile"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrUsernameTaken nom boshqa foydalanuvchida yoki yaqinda bo'shatilgan
+var ErrUsernameTaken = errors.New("username is already taken")
+
+type UsernameChange struct {
+	OldUsername string `json:"old_username"`
+	NewUsername string `json:"new_username"`
+	ChangedAt   string `json:"changed_at"`
+}
+
+// UsernameReleased nomni boshqa foydalanuvchi cooldown ichida bo'shatganmi.
+// exceptUserId o'zining eski nomini qaytarib olishi mumkin.
+func (u *UserRepo) UsernameReleased(name, exceptUserId string, cooldown time.Duration) (bool, error) {
+	var released bool
+	err := u.DB.QueryRow(`
+		SELECT
+			EXISTS (
+				SELECT
+					1
+				FROM
+					username_history
+				WHERE
+					LOWER(old_username) = LOWER($1)
+					AND user_id::TEXT <> $2
+					AND changed_at > CURRENT_TIMESTAMP - MAKE_INTERVAL(secs => $3)
+			)
+	`, name, exceptUserId, cooldown.Seconds()).Scan(&released)
+
+	return released, err
+}
+
+// ChangeUsername users va user_profiles dagi nomni bitta tranzaksiyada
+// o'zgartiradi, tarixga yozadi va eski nomni qaytaradi
+func (u *UserRepo) ChangeUsername(userId, name string, cooldown time.Duration, change profile.Change) (string, error) {
+	tx, err := u.DB.Begin()
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var old string
+	err = tx.QueryRow(`
+		SELECT
+			COALESCE(username, '')
+		FROM
+			users
+		WHERE
+			id::TEXT = $1
+		FOR UPDATE
+	`, userId).Scan(&old)
+	if err != nil {
+		return "", err
+	}
+	if old == name {
+		return old, nil
+	}
+
+	// Faqat harf katta-kichikligi o'zgarsa band tekshiruvi kerak emas
+	if !strings.EqualFold(old, name) {
+		var taken bool
+		err = tx.QueryRow(`
+			SELECT
+				EXISTS (SELECT 1 FROM users WHERE LOWER(username) = LOWER($1))
+				OR EXISTS (
+					SELECT
+						1
+					FROM
+						username_history
+					WHERE
+						LOWER(old_username) = LOWER($1)
+						AND user_id::TEXT <> $2
+						AND changed_at > CURRENT_TIMESTAMP - MAKE_INTERVAL(secs => $3)
+				)
+		`, name, userId, cooldown.Seconds()).Scan(&taken)
+		if err != nil {
+			return "", err
+		}
+		if taken {
+			return old, ErrUsernameTaken
+		}
+	}
+
+	for _, query := range []string{
+		`UPDATE users SET username = $2, updated_at = CURRENT_TIMESTAMP WHERE id::TEXT = $1`,
+		`UPDATE user_profiles SET username = $2, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE user_id::TEXT = $1`,
+	} {
+		if _, err := tx.Exec(query, userId, name); err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+				return old, ErrUsernameTaken
+			}
+			return old, err
+		}
+	}
+
+	if old != "" {
+		_, err = tx.Exec(`
+			INSERT INTO username_history (
+				user_id,
+				old_username,
+				new_username
+			)
+			VALUES (
+				$1,
+				$2,
+				$3
+			)
+		`, userId, old, name)
+		if err != nil {
+			return old, err
+		}
+	}
+
+	if err := recordProfileVersion(tx, userId, change); err != nil {
+		return old, err
+	}
+	return old, tx.Commit()
+}
+
+// ListUsernameHistory foydalanuvchining oldingi nomlari, yangisi birinchi
+func (u *UserRepo) ListUsernameHistory(userId string) ([]*UsernameChange, error) {
+	rows, err := u.DB.Query(`
+		SELECT
+			old_username,
+			new_username,
+			TO_CHAR(changed_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')
+		FROM
+			username_history
+		WHERE
+			user_id::TEXT = $1
+		ORDER BY
+			changed_at DESC
+	`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := []*UsernameChange{}
+	for rows.Next() {
+		var change UsernameChange
+		if err := rows.Scan(&change.OldUsername, &change.NewUsername, &change.ChangedAt); err != nil {
+			return nil, err
+		}
+		history = append(history, &change)
+	}
+
+	return history, rows.Err()
+}
+
+// resolveOldUsername eski nom bo'yicha uni oxirgi ishlatgan foydalanuvchini topadi
+func (u *UserRepo) resolveOldUsername(name string) (string, error) {
+	var userId string
+	err := u.DB.QueryRow(`
+		SELECT
+			user_id
+		FROM
+			username_history
+		WHERE
+			LOWER(old_username) = LOWER($1)
+		ORDER BY
+			changed_at DESC
+		LIMIT 1
+	`, name).Scan(&userId)
+
+	return userId, err
+}